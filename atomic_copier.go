@@ -0,0 +1,67 @@
+package deepcopy
+
+import (
+	"fmt"
+	"reflect"
+)
+
+const (
+	atomicMethodLoad  = "Load"
+	atomicMethodStore = "Store"
+)
+
+// atomicCopier data structure of copier that copies between `sync/atomic` types.
+// Values are read and written via the `Load` and `Store` methods of the types.
+type atomicCopier struct {
+	ctx            *Context
+	srcLoadMethod  int
+	dstStoreMethod int
+	dstStoreType   reflect.Type
+	dstResetFirst  bool
+	copier         copier
+}
+
+// Copy implementation of Copy function for atomic copier
+func (c *atomicCopier) Copy(dst, src reflect.Value) error {
+	if !src.CanAddr() {
+		srcCopy := reflect.New(src.Type()).Elem()
+		srcCopy.Set(src)
+		src = srcCopy
+	}
+	val := src.Addr().Method(c.srcLoadMethod).Call(nil)[0]
+	// atomic.Value holds nothing, reset the destination
+	if val.Kind() == reflect.Interface && val.IsNil() {
+		dst.Set(reflect.Zero(dst.Type())) // NOTE: Go1.18 has no SetZero
+		return nil
+	}
+
+	newVal := reflect.New(c.dstStoreType).Elem()
+	if err := c.copier.Copy(newVal, val); err != nil {
+		return err
+	}
+	if c.dstResetFirst {
+		// NOTE: atomic.Value panics when storing a value of type different from the current one
+		dst.Set(reflect.Zero(dst.Type())) // NOTE: Go1.18 has no SetZero
+	}
+	dst.Addr().Method(c.dstStoreMethod).Call([]reflect.Value{newVal})
+	return nil
+}
+
+func (c *atomicCopier) init(dstType, srcType reflect.Type) (err error) {
+	loadMethod, ok := reflect.PointerTo(srcType).MethodByName(atomicMethodLoad)
+	if !ok || loadMethod.Type.NumIn() != 1 || loadMethod.Type.NumOut() != 1 {
+		return fmt.Errorf("%w: %v has no valid Load method", ErrTypeNonCopyable, srcType)
+	}
+	storeMethod, ok := reflect.PointerTo(dstType).MethodByName(atomicMethodStore)
+	if !ok || storeMethod.Type.NumIn() != 2 || storeMethod.Type.NumOut() != 0 {
+		return fmt.Errorf("%w: %v has no valid Store method", ErrTypeNonCopyable, dstType)
+	}
+	c.srcLoadMethod = loadMethod.Index
+	c.dstStoreMethod = storeMethod.Index
+	c.dstStoreType = storeMethod.Type.In(1)
+	c.dstResetFirst = c.dstStoreType.Kind() == reflect.Interface
+
+	// Copying between loaded and stored values follows the normal rules such as numeric conversion
+	c.copier, err = buildCopier(c.ctx, c.dstStoreType, loadMethod.Type.Out(0))
+	return err
+}
@@ -0,0 +1,105 @@
+//go:build go1.19
+
+package deepcopy
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Copy_atomic(t *testing.T) {
+	t.Run("#1: copy atomic values", func(t *testing.T) {
+		type SS struct {
+			B atomic.Bool
+			I atomic.Int64
+			U atomic.Uint32
+			V atomic.Value
+		}
+		s := &SS{}
+		s.B.Store(true)
+		s.I.Store(-123)
+		s.U.Store(123)
+		s.V.Store("abc")
+		var d SS
+		err := Copy(&d, s)
+		assert.Nil(t, err)
+		assert.Equal(t, true, d.B.Load())
+		assert.Equal(t, int64(-123), d.I.Load())
+		assert.Equal(t, uint32(123), d.U.Load())
+		assert.Equal(t, "abc", d.V.Load())
+	})
+
+	t.Run("#2: copy between different atomic widths", func(t *testing.T) {
+		type SS struct {
+			I atomic.Int64
+			U atomic.Uint32
+		}
+		type DD struct {
+			I atomic.Int32
+			U atomic.Int64
+		}
+		s := &SS{}
+		s.I.Store(1<<31 + 1)
+		s.U.Store(123)
+		var d DD
+		err := Copy(&d, s)
+		assert.Nil(t, err)
+		assert.Equal(t, int32(-1<<31+1), d.I.Load())
+		assert.Equal(t, int64(123), d.U.Load())
+	})
+
+	t.Run("#3: copy atomic pointers deeply", func(t *testing.T) {
+		type A struct {
+			I int
+		}
+		type B struct {
+			I int64
+		}
+		type SS struct {
+			P  atomic.Pointer[A]
+			NP atomic.Pointer[A]
+		}
+		type DD struct {
+			P  atomic.Pointer[B]
+			NP atomic.Pointer[B]
+		}
+		s := &SS{}
+		s.P.Store(&A{I: 1})
+		var d DD
+		d.NP.Store(&B{I: 2})
+		err := Copy(&d, s)
+		assert.Nil(t, err)
+		assert.Equal(t, &B{I: 1}, d.P.Load())
+		assert.Nil(t, d.NP.Load())
+	})
+
+	t.Run("#4: copy atomic.Value holding nothing", func(t *testing.T) {
+		var s, d atomic.Value
+		d.Store(1)
+		err := Copy(&d, &s)
+		assert.Nil(t, err)
+		assert.Nil(t, d.Load())
+
+		// Store a value of different type
+		s.Store("abc")
+		err = Copy(&d, &s)
+		assert.Nil(t, err)
+		assert.Equal(t, "abc", d.Load())
+	})
+}
+
+func Test_Copy_atomic_error(t *testing.T) {
+	t.Run("#1: atomic values of non-copyable types", func(t *testing.T) {
+		type SS struct {
+			I atomic.Int64
+		}
+		type DD struct {
+			I atomic.Bool
+		}
+		var d DD
+		err := Copy(&d, &SS{})
+		assert.ErrorIs(t, err, ErrTypeNonCopyable)
+	})
+}
@@ -3,6 +3,8 @@ package deepcopy
 import (
 	"fmt"
 	"reflect"
+	"sync"
+	"unsafe"
 )
 
 // copier base interface defines Copy function
@@ -109,6 +111,50 @@ func (c *convCopier) Copy(dst, src reflect.Value) error {
 
 var defaultConvCopier = &convCopier{}
 
+// zeroCopier copier that sets `dst` to zero value
+type zeroCopier struct {
+}
+
+func (c *zeroCopier) Copy(dst, src reflect.Value) error {
+	dst.Set(reflect.Zero(dst.Type())) // NOTE: Go1.18 has no SetZero
+	return nil
+}
+
+var defaultZeroCopier = &zeroCopier{}
+
+// condCopier copier of `sync.Cond` values, it resets the destination to zero state but keeps its locker.
+// When the destination has no locker, it shares the source one, so the destination is usable.
+type condCopier struct {
+}
+
+func (c *condCopier) Copy(dst, src reflect.Value) error {
+	locker := dst.Field(condLockerIndex).Interface()
+	if locker == nil {
+		srcLocker := src.Field(condLockerIndex)
+		if !srcLocker.CanInterface() && srcLocker.CanAddr() {
+			srcLocker = reflect.NewAt(srcLocker.Type(), unsafe.Pointer(srcLocker.UnsafeAddr())).Elem() //nolint:gosec
+		}
+		if srcLocker.CanInterface() {
+			locker = srcLocker.Interface()
+		}
+	}
+	dst.Set(reflect.Zero(dst.Type())) // NOTE: Go1.18 has no SetZero
+	if locker != nil {
+		dst.Field(condLockerIndex).Set(reflect.ValueOf(locker))
+	}
+	return nil
+}
+
+var (
+	defaultCondCopier = &condCopier{}
+
+	// condLockerIndex index of field `L` of `sync.Cond`
+	condLockerIndex = func() int {
+		field, _ := typeOf[sync.Cond]().FieldByName("L")
+		return field.Index[0]
+	}()
+)
+
 // inlineCopier copier that does copying on the fly.
 // This copier is usually used to avoid circular reference.
 type inlineCopier struct {
@@ -125,6 +171,20 @@ func (c *inlineCopier) Copy(dst, src reflect.Value) error {
 	return cp.Copy(dst, src)
 }
 
+// depthExceededCopier copier used for values nested deeper than the configured max depth.
+// Zero values are copied as there is nothing to go deeper into.
+type depthExceededCopier struct {
+	ignore bool
+}
+
+func (c *depthExceededCopier) Copy(dst, src reflect.Value) error {
+	if c.ignore || src.IsZero() {
+		dst.Set(reflect.Zero(dst.Type())) // NOTE: Go1.18 has no SetZero
+		return nil
+	}
+	return fmt.Errorf("%w: copying %v -> %v", ErrDepthExceeded, src.Type(), dst.Type())
+}
+
 // methodCopier copier that calls a copying method
 type methodCopier struct {
 	dstMethod int
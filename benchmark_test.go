@@ -0,0 +1,182 @@
+package deepcopy
+
+import (
+	"strconv"
+	"testing"
+)
+
+// benchJSONPayload returns a JSON-shaped payload of nested maps and slices
+func benchJSONPayload(numItems int) map[string]any {
+	items := make([]any, 0, numItems)
+	for i := 0; i < numItems; i++ {
+		items = append(items, map[string]any{
+			"id":     float64(i),
+			"name":   "item " + strconv.Itoa(i),
+			"active": i%2 == 0,
+			"tags":   []any{"a", "b", "c"},
+			"attrs": map[string]any{
+				"price": 1.5,
+				"stock": nil,
+			},
+		})
+	}
+	return map[string]any{
+		"total": float64(numItems),
+		"items": items,
+	}
+}
+
+func Benchmark_Copy_slice_of_iface(b *testing.B) {
+	src := make([]any, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		switch i % 4 {
+		case 0:
+			src = append(src, i)
+		case 1:
+			src = append(src, strconv.Itoa(i))
+		case 2:
+			src = append(src, float64(i))
+		default:
+			src = append(src, i%3 == 0)
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var dst []any
+		_ = Copy(&dst, src)
+	}
+}
+
+func Benchmark_Copy_nested_map_of_iface(b *testing.B) {
+	src := benchJSONPayload(100)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var dst map[string]any
+		_ = Copy(&dst, src)
+	}
+}
+
+func Benchmark_Copy_slice_of_pointer_free_structs(b *testing.B) {
+	type Point struct {
+		X, Y, Z float64
+		Tag     [4]byte
+		Valid   bool
+	}
+	src := make([]Point, 1000)
+	for i := range src {
+		src[i] = Point{X: float64(i), Y: float64(i * 2), Z: float64(i * 3), Valid: i%2 == 0}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var dst []Point
+		_ = Copy(&dst, src)
+	}
+}
+
+func Benchmark_Copy_large_slice_of_int(b *testing.B) {
+	src := make([]int, 100_000)
+	for i := range src {
+		src[i] = i
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var dst []int
+		_ = Copy(&dst, src)
+	}
+}
+
+func Benchmark_Copy_large_slice_of_string(b *testing.B) {
+	src := make([]string, 100_000)
+	for i := range src {
+		src[i] = strconv.Itoa(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var dst []string
+		_ = Copy(&dst, src)
+	}
+}
+
+func Benchmark_Copy_large_slice_of_int32_to_int64(b *testing.B) {
+	src := make([]int32, 100_000)
+	for i := range src {
+		src[i] = int32(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var dst []int64
+		_ = Copy(&dst, src)
+	}
+}
+
+func Benchmark_Copy_large_byte_array_to_slice(b *testing.B) {
+	src := &[64 * 1024]byte{}
+	for i := range src {
+		src[i] = byte(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var dst []byte
+		_ = Copy(&dst, src)
+	}
+}
+
+func Benchmark_Copy_struct_parallel(b *testing.B) {
+	type Item struct {
+		ID    int
+		Name  string
+		Price float64
+	}
+	type SS struct {
+		ID    int
+		Name  string
+		Items []Item
+		Attrs map[string]any
+	}
+	type DD struct {
+		ID    int64
+		Name  string
+		Items []*Item
+		Attrs map[string]any
+	}
+	src := SS{ID: 1, Name: "a", Items: []Item{{ID: 1, Name: "x", Price: 1.5}, {ID: 2}},
+		Attrs: map[string]any{"a": 1, "b": []any{"c", 2.5}}}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			var dst DD
+			_ = Copy(&dst, &src)
+		}
+	})
+}
+
+func Benchmark_Copy_nested_map_of_iface_parallel(b *testing.B) {
+	src := benchJSONPayload(10)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			var dst map[string]any
+			_ = Copy(&dst, src)
+		}
+	})
+}
+
+func Benchmark_Copy_large_slice_of_structs_in_parallel(b *testing.B) {
+	type Item struct {
+		ID   int
+		Name string
+		Tags []string
+	}
+	src := make([]Item, 100_000)
+	for i := range src {
+		src[i] = Item{ID: i, Name: strconv.Itoa(i), Tags: []string{"a", "b"}}
+	}
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run("workers="+strconv.Itoa(workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				var dst []Item
+				_ = Copy(&dst, src, Parallel(workers, 1000))
+			}
+		})
+	}
+}
@@ -4,24 +4,60 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
-	"sync"
+	"sync/atomic"
 )
 
 // cacheKey key data structure of cached copiers
 type cacheKey struct {
 	dstType reflect.Type
 	srcType reflect.Type
-	flags   uint8
+	options *copierOptions
+}
+
+// copierOptions settings of the context which copiers are built with.
+// They are interned, so contexts having the same settings share the same pointer which is a part of cache keys.
+type copierOptions struct {
+	flags uint16
+	// depthLeft number of nesting levels left plus one, 0 means unlimited
+	depthLeft int
+	// onlyMask, omitMask field masks applying at the current level
+	onlyMask *fieldMask
+	omitMask *fieldMask
+	// shallowTypes interned set of ShallowCopyTypes
+	shallowTypes *typeSet
+	// opaqueTypes interned set of OpaqueTypes
+	opaqueTypes  *typeSet
+	chanPolicy   ChanPolicy
+	unsafePolicy UnsafePolicy
+	textConv     TextConv
+	strictMode   StrictMode
+	// timeFormat time format parsed from tag options of the current struct field
+	timeFormat timeFormat
+	// parallelWorkers, parallelMinChunk settings of parallel copying, 0 workers means no parallel copying
+	parallelWorkers  int
+	parallelMinChunk int
 }
 
 var (
-	// copierCacheMap global cache for any parsed type
-	copierCacheMap = make(map[cacheKey]copier, 10) //nolint:mnd
+	// internedCopierOptions interned copier options
+	internedCopierOptions internMap[copierOptions, *copierOptions]
+	// lastPreparedOptions copier options of the last prepared context, the same options are usually
+	// used again and again, so they are found by comparing rather than hashing them
+	lastPreparedOptions atomic.Value // *copierOptions
+)
 
-	// mu read/write cache lock
-	mu sync.RWMutex
+// internCopierOptions returns the interned copier options equal to the given ones
+func internCopierOptions(options copierOptions) *copierOptions {
+	if interned, found := internedCopierOptions.get(options); found {
+		return interned
+	}
+	newOptions := options
+	return internedCopierOptions.add(options, &newOptions)
+}
 
+var (
 	// simpleKindMask mask for checking basic kinds such as int, string, ...
+	// NOTE: `uintptr` is not included as it's copied following the unsafe copy policy
 	simpleKindMask = func() uint32 {
 		n := uint32(0)
 		n |= 1 << reflect.Bool
@@ -40,7 +76,6 @@ var (
 		n |= 1 << reflect.Float64
 		n |= 1 << reflect.Complex64
 		n |= 1 << reflect.Complex128
-		n |= 1 << reflect.Uintptr
 		n |= 1 << reflect.Func
 		return n
 	}()
@@ -53,38 +88,120 @@ const (
 	flagCopyViaCopyingMethod = 2
 	// flagIgnoreNonCopyableTypes indicates copying will skip copying non-copyable types without raising errors
 	flagIgnoreNonCopyableTypes = 3
+	// flagIgnoreDepthExceeded indicates values nested deeper than the max depth will be zeroed without raising errors
+	flagIgnoreDepthExceeded = 4
+	// flagCopyViaDeepCopyMethod indicates copying will be performed via deep copy methods of types
+	flagCopyViaDeepCopyMethod = 5
+	// flagUseRegisteredCopyFuncs indicates copying will be performed via registered copy functions
+	flagUseRegisteredCopyFuncs = 6
+	// flagCopyIntoIfaceValue indicates copying will be performed into the values held by destination interfaces
+	flagCopyIntoIfaceValue = 7
+	// flagTimeConversion indicates time values will be converted from and to strings without time tag options
+	flagTimeConversion = 8
 )
 
 // prepare prepares context for copiers
-func (ctx *Context) prepare() {
+func (ctx *Context) prepare() (err error) {
 	if ctx.UseGlobalCache {
-		ctx.copierCacheMap = copierCacheMap
-		ctx.mu = &mu
+		ctx.copierCache = globalCopierCache
 	} else {
-		ctx.copierCacheMap = make(map[cacheKey]copier, 5) //nolint:mnd
-		ctx.mu = &sync.RWMutex{}
+		ctx.copierCache = &copierCache{}
 	}
 
-	// Recalculate the flags
-	ctx.flags = 0
+	options := copierOptions{
+		chanPolicy:   ctx.ChanCopyPolicy,
+		unsafePolicy: ctx.UnsafeCopyPolicy,
+		textConv:     ctx.TextConversion,
+		strictMode:   ctx.StrictMatching,
+	}
 	if ctx.CopyBetweenPtrAndValue {
-		ctx.flags |= 1 << flagCopyBetweenPtrAndValue
+		options.flags |= 1 << flagCopyBetweenPtrAndValue
 	}
 	if ctx.CopyViaCopyingMethod {
-		ctx.flags |= 1 << flagCopyViaCopyingMethod
+		options.flags |= 1 << flagCopyViaCopyingMethod
 	}
 	if ctx.IgnoreNonCopyableTypes {
-		ctx.flags |= 1 << flagIgnoreNonCopyableTypes
+		options.flags |= 1 << flagIgnoreNonCopyableTypes
+	}
+	if ctx.IgnoreDepthExceeded {
+		options.flags |= 1 << flagIgnoreDepthExceeded
 	}
+	if ctx.CopyViaDeepCopyMethod {
+		options.flags |= 1 << flagCopyViaDeepCopyMethod
+	}
+	if ctx.UseRegisteredCopyFuncs {
+		options.flags |= 1 << flagUseRegisteredCopyFuncs
+	}
+	if ctx.CopyIntoIfaceValue {
+		options.flags |= 1 << flagCopyIntoIfaceValue
+	}
+	if ctx.TimeConversion {
+		options.flags |= 1 << flagTimeConversion
+	}
+
+	if ctx.MaxDepth > 0 {
+		options.depthLeft = ctx.MaxDepth + 1
+	}
+
+	if ctx.ParallelWorkers > 1 {
+		options.parallelWorkers = ctx.ParallelWorkers
+		options.parallelMinChunk = ctx.ParallelMinChunk
+		if options.parallelMinChunk <= 0 {
+			options.parallelMinChunk = defaultParallelMinChunk
+		}
+	}
+
+	if options.onlyMask, err = compileFieldMask(ctx.OnlyFields); err != nil {
+		return err
+	}
+	if options.omitMask, err = compileFieldMask(ctx.OmitFields); err != nil {
+		return err
+	}
+	options.shallowTypes = internTypeSet(ctx.ShallowCopyTypes)
+	options.opaqueTypes = internTypeSet(ctx.OpaqueTypes)
+	if last, _ := lastPreparedOptions.Load().(*copierOptions); last != nil && *last == options {
+		ctx.copierOptions = last
+		return nil
+	}
+	ctx.copierOptions = internCopierOptions(options)
+	lastPreparedOptions.Store(ctx.copierOptions)
+	return nil
 }
 
-// createCacheKey creates and returns  key for caching a copier
-func (ctx *Context) createCacheKey(dstType, srcType reflect.Type) *cacheKey {
-	return &cacheKey{
-		dstType: dstType,
-		srcType: srcType,
-		flags:   ctx.flags,
+// withOptions returns a context with the given copier options
+func (ctx *Context) withOptions(options copierOptions) *Context {
+	newCtx := *ctx
+	newCtx.copierOptions = internCopierOptions(options)
+	return &newCtx
+}
+
+// deeper returns a context for building copiers of the next nesting level.
+// When max depth is not set, the context itself is returned.
+func (ctx *Context) deeper() *Context {
+	if ctx.depthLeft == 0 {
+		return ctx
 	}
+	options := *ctx.copierOptions
+	options.depthLeft--
+	return ctx.withOptions(options)
+}
+
+// createCacheKey creates and returns key for caching a copier
+func (ctx *Context) createCacheKey(dstType, srcType reflect.Type) cacheKey {
+	return cacheKey{dstType: dstType, srcType: srcType, options: ctx.copierOptions}
+}
+
+// simpleConvertible checks if values of simple kinds can be copied via Go conversion
+// as no special conversions such as the text ones or the unsafe copy policy apply
+func (ctx *Context) simpleConvertible(dstType, srcType reflect.Type) bool {
+	return srcType.ConvertibleTo(dstType) && ctx.TextConversion == TextConvNone &&
+		(srcType != durationType || dstType.Kind() != reflect.String || !ctx.TimeConversion) &&
+		!ctx.unsafeKind(dstType.Kind()) && !ctx.unsafeKind(srcType.Kind())
+}
+
+// unsafeKind checks if values of the kind must be copied following the unsafe copy policy
+func (ctx *Context) unsafeKind(kind reflect.Kind) bool {
+	return kind == reflect.UnsafePointer || (kind == reflect.Uintptr && ctx.UnsafeCopyPolicy != UnsafePolicyDefault)
 }
 
 // defaultContext creates a default context
@@ -93,6 +210,7 @@ func defaultContext() *Context {
 		CopyBetweenPtrAndValue: true,
 		CopyViaCopyingMethod:   true,
 		UseGlobalCache:         true,
+		UseRegisteredCopyFuncs: true,
 	}
 }
 
@@ -102,15 +220,107 @@ func defaultContext() *Context {
 func buildCopier(ctx *Context, dstType, srcType reflect.Type) (copier copier, err error) {
 	// Finds cached copier, returns it if found
 	cacheKey := ctx.createCacheKey(dstType, srcType)
-	ctx.mu.RLock()
-	cachedCopier, cachedCopierFound := ctx.copierCacheMap[*cacheKey]
-	ctx.mu.RUnlock()
+	cachedCopier, cachedCopierFound := ctx.copierCache.get(cacheKey)
 	if cachedCopier != nil {
 		return cachedCopier, nil
 	}
 
 	dstKind, srcKind := dstType.Kind(), srcType.Kind()
 
+	// Field masks can only apply to structs or values containing structs
+	if ctx.hasFieldMasks() && simpleKindMask&(1<<srcKind) > 0 && simpleKindMask&(1<<dstKind) > 0 {
+		return nil, ctx.fieldMasksErrNotApplicable(dstType, srcType)
+	}
+
+	// Structs having registered copy functions such as generated ones
+	if srcKind == reflect.Struct && dstKind == reflect.Struct {
+		if copier = buildCopierForCopyFuncs(ctx, dstType, srcType); copier != nil {
+			goto OnComplete
+		}
+	}
+
+	// Values of shallow copy types are assigned as is
+	if (ctx.shallowTypes.has(srcType) || ctx.shallowTypes.has(dstType)) && srcType.AssignableTo(dstType) {
+		if ctx.hasFieldMasks() {
+			return nil, ctx.fieldMasksErrNotApplicable(dstType, srcType)
+		}
+		copier = defaultDirectCopier
+		goto OnComplete
+	}
+
+	// Types having their own deep copy methods such as `DeepCopyInto(out *T)` copy themselves
+	if ctx.CopyViaDeepCopyMethod && dstType == srcType && srcKind != reflect.Interface && !ctx.hasFieldMasks() {
+		if copier = buildCopierForDeepCopyMethods(srcType); copier != nil {
+			goto OnComplete
+		}
+	}
+
+	// Opaque types are never looked inside, they are always copied by value
+	if ctx.opaqueTypes.has(srcType) && dstKind != reflect.Interface {
+		copier = buildCopierForOpaqueTypes(dstType, srcType)
+		if copier == nil {
+			goto OnNonCopyable
+		}
+		if ctx.hasFieldMasks() {
+			return nil, ctx.fieldMasksErrNotApplicable(dstType, srcType)
+		}
+		goto OnComplete
+	}
+
+	// Unsafe pointers and uintptr follow the unsafe copy policy, so do values copied to them
+	if (srcKind == reflect.UnsafePointer || srcKind == reflect.Uintptr ||
+		dstKind == reflect.UnsafePointer || dstKind == reflect.Uintptr) && dstKind != reflect.Interface {
+		copier = buildCopierForUnsafeTypes(ctx, dstType, srcType)
+		if copier == nil {
+			goto OnNonCopyable
+		}
+		if ctx.hasFieldMasks() {
+			return nil, ctx.fieldMasksErrNotApplicable(dstType, srcType)
+		}
+		goto OnComplete
+	}
+
+	// Go standard types having special copiers such as big.Int, bytes.Buffer
+	if copier = buildCopierForStandardTypes(dstType, srcType); copier != nil {
+		if ctx.hasFieldMasks() {
+			return nil, ctx.fieldMasksErrNotApplicable(dstType, srcType)
+		}
+		goto OnComplete
+	}
+
+	// Copying between `sql.Null*` types and other types such as sql.NullString -> *string
+	if copier, err = buildCopierForSQLNullTypes(ctx, dstType, srcType); err != nil || copier != nil {
+		if err == nil && ctx.hasFieldMasks() {
+			return nil, ctx.fieldMasksErrNotApplicable(dstType, srcType)
+		}
+		goto OnComplete
+	}
+
+	// Conversions of time.Time and time.Duration such as time.Time -> string
+	if copier = buildCopierForTimeTypes(ctx, dstType, srcType); copier != nil {
+		if ctx.hasFieldMasks() {
+			return nil, ctx.fieldMasksErrNotApplicable(dstType, srcType)
+		}
+		goto OnComplete
+	}
+
+	// Conversions between text and types implementing text interfaces such as `encoding.TextMarshaler`
+	if copier = buildCopierForTextTypes(ctx, dstType, srcType); copier != nil {
+		if ctx.hasFieldMasks() {
+			return nil, ctx.fieldMasksErrNotApplicable(dstType, srcType)
+		}
+		goto OnComplete
+	}
+
+	// Copying from a `driver.Valuer` to a `sql.Scanner` of other struct types goes via these interfaces
+	if srcKind == reflect.Struct && dstKind == reflect.Struct && isSQLValuerToScanner(dstType, srcType) {
+		if ctx.hasFieldMasks() {
+			return nil, ctx.fieldMasksErrNotApplicable(dstType, srcType)
+		}
+		copier = buildCopierForSQLValuerScanner(ctx, dstType, srcType)
+		goto OnComplete
+	}
+
 	// Trivial case
 	if simpleKindMask&(1<<srcKind) > 0 {
 		if dstType == srcType {
@@ -123,6 +333,20 @@ func buildCopier(ctx *Context, dstType, srcType reflect.Type) (copier copier, er
 		}
 	}
 
+	// Values of pointer-free structs and arrays are copied as a whole
+	if dstType == srcType && (srcKind == reflect.Struct || srcKind == reflect.Array) &&
+		ctx.depthLeft == 0 && !ctx.hasFieldMasks() && ctx.bulkCopyable(srcType) {
+		copier = defaultBulkCopier
+		goto OnComplete
+	}
+
+	// Values nested deeper than the max depth are not copied
+	if ctx.depthLeft == 1 {
+		return &depthExceededCopier{ignore: ctx.IgnoreDepthExceeded}, nil
+	}
+	// Copiers of inner values are built at the next nesting level
+	ctx = ctx.deeper()
+
 	if dstKind == reflect.Interface {
 		cp := &toIfaceCopier{ctx: ctx}
 		copier, err = cp, cp.init(dstType, srcType)
@@ -160,6 +384,23 @@ func buildCopier(ctx *Context, dstType, srcType reflect.Type) (copier copier, er
 	}
 
 	// Both are not Pointers
+	if srcKind == reflect.Chan {
+		if dstKind != reflect.Chan || dstType.Elem() != srcType.Elem() ||
+			ctx.ChanCopyPolicy == ChanPolicyNonCopyable {
+			goto OnNonCopyable
+		}
+		// Channels can be shared only when the source type is convertible to the destination one,
+		// e.g. `chan T` -> `<-chan T`
+		if ctx.ChanCopyPolicy == ChanPolicyShare && !srcType.ConvertibleTo(dstType) {
+			goto OnNonCopyable
+		}
+		if ctx.hasFieldMasks() {
+			return nil, ctx.fieldMasksErrNotApplicable(dstType, srcType)
+		}
+		copier = &chanCopier{policy: ctx.ChanCopyPolicy}
+		goto OnComplete
+	}
+
 	if srcKind == reflect.Slice || srcKind == reflect.Array {
 		if dstKind != reflect.Slice && dstKind != reflect.Array {
 			goto OnNonCopyable
@@ -173,8 +414,14 @@ func buildCopier(ctx *Context, dstType, srcType reflect.Type) (copier copier, er
 	if srcKind == reflect.Struct {
 		if dstKind == reflect.Struct {
 			// Build a special copier for Go standard types such as time.Time, unique.Handle
-			copier = buildCopierForStandardStructs(dstType, srcType)
+			copier, err = buildCopierForStandardStructs(ctx, dstType, srcType)
+			if err != nil {
+				return nil, err
+			}
 			if copier != nil {
+				if ctx.hasFieldMasks() {
+					return nil, ctx.fieldMasksErrNotApplicable(dstType, srcType)
+				}
 				goto OnComplete
 			}
 
@@ -209,8 +456,17 @@ func buildCopier(ctx *Context, dstType, srcType reflect.Type) (copier copier, er
 			goto OnComplete
 		}
 		if dstKind == reflect.Struct {
+			// Copiers of map entries are built in advance, so circular references can happen as well
+			if cachedCopierFound {
+				return &inlineCopier{ctx: ctx, dstType: dstType, srcType: srcType}, nil
+			}
+			setCachedCopier(ctx, cacheKey, nil)
+
 			cp := &mapToStructCopier{ctx: ctx}
 			copier, err = cp, cp.init(dstType, srcType)
+			if err != nil {
+				deleteCachedCopier(ctx, cacheKey)
+			}
 			goto OnComplete
 		}
 		goto OnNonCopyable
@@ -227,46 +483,99 @@ OnComplete:
 	}
 
 OnNonCopyable:
+	// Types implementing `driver.Valuer` or `sql.Scanner` can be copied via these interfaces
+	if copier = buildCopierForSQLValuerScanner(ctx, dstType, srcType); copier != nil {
+		setCachedCopier(ctx, cacheKey, copier)
+		return copier, nil
+	}
 	if ctx.IgnoreNonCopyableTypes {
 		return defaultNopCopier, nil
 	}
 	return nil, fmt.Errorf("%w: %v -> %v", ErrTypeNonCopyable, srcType, dstType)
 }
 
-func buildCopierForStandardStructs(dstType, srcType reflect.Type) copier {
+// buildCopierForStandardStructs builds special copier for Go standard struct types,
+// returns nil if the types are not handled specially
+func buildCopierForStandardStructs(ctx *Context, dstType, srcType reflect.Type) (copier, error) {
 	switch srcType.PkgPath() {
 	// When copy time.Time -> time.Time or derived type
 	case "time":
 		if srcType.Name() == "Time" {
 			if dstType == srcType {
-				return defaultDirectCopier
+				return defaultDirectCopier, nil
 			}
 			if dstType.ConvertibleTo(srcType) {
-				return defaultConvCopier
+				return defaultConvCopier, nil
 			}
 		}
 	// When copy unique.Handle[T] -> unique.Handle[T] or derived type
 	case "unique":
 		if strings.HasPrefix(srcType.Name(), "Handle[") {
 			if dstType == srcType {
-				return defaultDirectCopier
+				return defaultDirectCopier, nil
 			}
 			if dstType.ConvertibleTo(srcType) {
-				return defaultConvCopier
+				return defaultConvCopier, nil
+			}
+		}
+	// When copy sync.Mutex, sync.WaitGroup, ... -> the same type or derived type,
+	// the destination is reset to zero state as copying a lock or an in-use primitive is wrong
+	case "sync":
+		switch srcType.Name() {
+		case "Mutex", "RWMutex", "Once", "WaitGroup":
+			if dstType == srcType || dstType.ConvertibleTo(srcType) {
+				return defaultZeroCopier, nil
+			}
+		case "Cond":
+			if dstType == srcType || dstType.ConvertibleTo(srcType) {
+				return defaultCondCopier, nil
 			}
 		}
+	// When copy atomic.Int64, atomic.Pointer[T], ... -> any atomic type
+	case "sync/atomic":
+		if dstType.PkgPath() == srcType.PkgPath() {
+			cp := &atomicCopier{ctx: ctx}
+			if err := cp.init(dstType, srcType); err != nil {
+				return nil, err
+			}
+			return cp, nil
+		}
+	}
+	return nil, nil
+}
+
+// buildCopierForUnsafeTypes builds copier for `unsafe.Pointer` and `uintptr` values
+// following the unsafe copy policy, returns nil if the types are non-copyable
+func buildCopierForUnsafeTypes(ctx *Context, dstType, srcType reflect.Type) copier {
+	switch ctx.UnsafeCopyPolicy {
+	case UnsafePolicyError:
+		return nil
+	case UnsafePolicyZero:
+		return defaultZeroCopier
+	case UnsafePolicyDefault:
+		if srcType.Kind() == reflect.UnsafePointer || dstType.Kind() == reflect.UnsafePointer {
+			return nil
+		}
+	case UnsafePolicyShare:
+	}
+	return buildCopierForOpaqueTypes(dstType, srcType)
+}
+
+// buildCopierForOpaqueTypes builds copier copying values as is, returns nil if the types are not convertible
+func buildCopierForOpaqueTypes(dstType, srcType reflect.Type) copier {
+	if dstType == srcType {
+		return defaultDirectCopier
+	}
+	if srcType.ConvertibleTo(dstType) {
+		return defaultConvCopier
 	}
 	return nil
 }
 
-func setCachedCopier(ctx *Context, cacheKey *cacheKey, cp copier) {
-	ctx.mu.Lock()
-	ctx.copierCacheMap[*cacheKey] = cp
-	ctx.mu.Unlock()
+func setCachedCopier(ctx *Context, cacheKey cacheKey, cp copier) {
+	ctx.copierCache.set(cacheKey, cp)
 }
 
-func deleteCachedCopier(ctx *Context, cacheKey *cacheKey) {
-	ctx.mu.Lock()
-	delete(ctx.copierCacheMap, *cacheKey)
-	ctx.mu.Unlock()
+func deleteCachedCopier(ctx *Context, cacheKey cacheKey) {
+	ctx.copierCache.delete(cacheKey)
 }
@@ -2,6 +2,7 @@ package deepcopy
 
 import (
 	"testing"
+	"unsafe"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -12,18 +13,160 @@ func Test_Ctx_prepare(t *testing.T) {
 	CopyBetweenPtrAndValue(false)(ctx)
 	CopyBetweenStructFieldAndMethod(false)(ctx)
 	IgnoreNonCopyableTypes(false)(ctx)
+	UseRegisteredCopyFuncs(false)(ctx)
 	ctx.prepare()
-	assert.Equal(t, uint8(0), ctx.flags)
+	assert.Equal(t, uint16(0), ctx.flags)
 
 	UseGlobalCache(false)(ctx)
 	ctx.prepare()
-	assert.True(t, &copierCacheMap != &ctx.copierCacheMap)
-	assert.True(t, &mu != ctx.mu)
+	assert.True(t, globalCopierCache != ctx.copierCache)
 
 	UseGlobalCache(true)(ctx)
 	ctx.prepare()
-	ctx.copierCacheMap[cacheKey{flags: 1}] = nil
-	copierCacheMap[cacheKey{flags: 2}] = nil
-	assert.Equal(t, copierCacheMap, ctx.copierCacheMap)
-	assert.True(t, &mu == ctx.mu)
+	assert.True(t, globalCopierCache == ctx.copierCache)
+	key := ctx.createCacheKey(typeOf[int](), typeOf[int]())
+	ctx.copierCache.set(key, nil)
+	cp, found := globalCopierCache.get(key)
+	assert.Nil(t, cp)
+	assert.True(t, found)
+	globalCopierCache.delete(key)
+	_, found = ctx.copierCache.get(key)
+	assert.False(t, found)
+
+	// Contexts having the same settings share the same copier options
+	ctx2 := defaultContext()
+	assert.Nil(t, ctx2.prepare())
+	ctx3 := defaultContext()
+	assert.Nil(t, ctx3.prepare())
+	assert.True(t, ctx2.copierOptions == ctx3.copierOptions)
+	assert.True(t, ctx2.copierOptions != ctx.copierOptions)
+	MaxDepth(2)(ctx2)
+	assert.Nil(t, ctx2.prepare())
+	MaxDepth(3)(ctx3)
+	assert.Nil(t, ctx3.prepare())
+	assert.True(t, ctx2.copierOptions != ctx3.copierOptions)
+	assert.True(t, ctx2.copierOptions == ctx3.deeper().copierOptions)
+}
+
+func Test_Copy_unsafeTypes(t *testing.T) {
+	type Handle struct {
+		p unsafe.Pointer
+		n int
+	}
+	type SS struct {
+		I int
+		P unsafe.Pointer
+		U uintptr
+		H Handle
+	}
+	x := 1
+	s := SS{I: 1, P: unsafe.Pointer(&x), U: 123, H: Handle{p: unsafe.Pointer(&x), n: 1}}
+
+	t.Run("#1: default policy", func(t *testing.T) {
+		var d uintptr
+		err := Copy(&d, s.U)
+		assert.Nil(t, err)
+		assert.Equal(t, s.U, d)
+
+		var d2 SS
+		err = Copy(&d2, s)
+		assert.ErrorIs(t, err, ErrTypeNonCopyable)
+
+		var d3 Handle
+		err = Copy(&d3, &s.H, OpaqueType[Handle]())
+		assert.Nil(t, err)
+		assert.Equal(t, s.H, d3)
+	})
+
+	t.Run("#2: share policy", func(t *testing.T) {
+		var d SS
+		err := Copy(&d, &s, UnsafeCopyPolicy(UnsafePolicyShare), OpaqueType[Handle]())
+		assert.Nil(t, err)
+		assert.Equal(t, s, d)
+
+		// Without marking the struct type as opaque, its unexported fields are copied one by one
+		d = SS{}
+		err = Copy(&d, &s, UnsafeCopyPolicy(UnsafePolicyShare))
+		assert.Nil(t, err)
+		assert.Equal(t, s, d)
+
+		var d2 any
+		err = Copy(&d2, s.P, UnsafeCopyPolicy(UnsafePolicyShare))
+		assert.Nil(t, err)
+		assert.Equal(t, s.P, d2)
+	})
+
+	t.Run("#3: zero policy", func(t *testing.T) {
+		// Opaque types are still copied by value
+		d := SS{U: 1}
+		err := Copy(&d, &s, UnsafeCopyPolicy(UnsafePolicyZero), OpaqueType[Handle]())
+		assert.Nil(t, err)
+		assert.Equal(t, SS{I: 1, H: s.H}, d)
+
+		d2 := uintptr(1)
+		err = Copy(&d2, 123, UnsafeCopyPolicy(UnsafePolicyZero))
+		assert.Nil(t, err)
+		assert.Equal(t, uintptr(0), d2)
+	})
+
+	t.Run("#4: error policy", func(t *testing.T) {
+		var d uintptr
+		err := Copy(&d, s.U, UnsafeCopyPolicy(UnsafePolicyError))
+		assert.ErrorIs(t, err, ErrTypeNonCopyable)
+
+		err = Copy(&d, 123, UnsafeCopyPolicy(UnsafePolicyError))
+		assert.ErrorIs(t, err, ErrTypeNonCopyable)
+
+		var d2 Handle
+		err = Copy(&d2, &s.H, UnsafeCopyPolicy(UnsafePolicyError), OpaqueType[Handle]())
+		assert.Nil(t, err)
+		assert.Equal(t, s.H, d2)
+
+		var d3 SS
+		err = Copy(&d3, &s, UnsafeCopyPolicy(UnsafePolicyError), IgnoreNonCopyableTypes(true))
+		assert.Nil(t, err)
+		assert.Equal(t, SS{I: 1, H: Handle{n: 1}}, d3)
+	})
+
+	t.Run("#5: converting struct fields and map entries follows the policy", func(t *testing.T) {
+		type SI struct {
+			U int
+		}
+		type DU struct {
+			U uintptr
+		}
+		for _, policy := range []UnsafePolicy{UnsafePolicyDefault, UnsafePolicyShare, UnsafePolicyZero,
+			UnsafePolicyError} {
+			want := DU{U: 123}
+			wantMap := map[uintptr]uintptr{1: 123}
+			if policy == UnsafePolicyZero {
+				want, wantMap = DU{}, map[uintptr]uintptr{0: 0}
+			}
+
+			var d DU
+			err := Copy(&d, SI{U: 123}, UnsafeCopyPolicy(policy))
+			var d2 map[uintptr]uintptr
+			err2 := Copy(&d2, map[int]int{1: 123}, UnsafeCopyPolicy(policy))
+			var d3 DU
+			err3 := Copy(&d3, map[string]int{"U": 123}, UnsafeCopyPolicy(policy))
+			var d4 map[string]uintptr
+			err4 := Copy(&d4, SI{U: 123}, UnsafeCopyPolicy(policy))
+
+			if policy == UnsafePolicyError {
+				assert.ErrorIs(t, err, ErrTypeNonCopyable)
+				assert.ErrorIs(t, err2, ErrTypeNonCopyable)
+				assert.ErrorIs(t, err3, ErrTypeNonCopyable)
+				assert.ErrorIs(t, err4, ErrTypeNonCopyable)
+				continue
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, want, d)
+			assert.Nil(t, err2)
+			assert.Equal(t, wantMap, d2)
+			assert.Nil(t, err3)
+			assert.Equal(t, want, d3)
+			assert.Nil(t, err4)
+			assert.Equal(t, map[string]uintptr{"U": want.U}, d4)
+		}
+	})
 }
@@ -0,0 +1,72 @@
+package deepcopy
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// bulkCopier data structure of copier that copies values of pointer-free types as a whole.
+// As the values contain no pointers, slices, maps, strings or interfaces, a single assignment
+// makes a deep copy including unexported fields.
+type bulkCopier struct {
+}
+
+// Copy implementation of Copy function for bulk copier
+func (c *bulkCopier) Copy(dst, src reflect.Value) error {
+	if !src.CanInterface() && src.CanAddr() {
+		src = reflect.NewAt(src.Type(), unsafe.Pointer(src.UnsafeAddr())).Elem() //nolint:gosec
+	}
+	dst.Set(src)
+	return nil
+}
+
+var defaultBulkCopier = &bulkCopier{}
+
+// bulkCopyable checks if values of the struct or array type can be copied as a whole.
+// The type must be made only of scalars, and no struct inside it can have special copying
+// such as ignored fields, copying methods, `PostCopy` or copiers of standard types.
+func (ctx *Context) bulkCopyable(typ reflect.Type) bool {
+	// Opaque types are always copied by value
+	if ctx.opaqueTypes.has(typ) {
+		return true
+	}
+	switch typ.Kind() { //nolint:exhaustive
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	case reflect.Array:
+		return ctx.bulkCopyable(typ.Elem())
+	case reflect.Struct:
+		return ctx.bulkCopyableStruct(typ)
+	default:
+		return false
+	}
+}
+
+// bulkCopyableStruct checks if values of the struct type can be copied as a whole
+func (ctx *Context) bulkCopyableStruct(typ reflect.Type) bool {
+	if pkgPath := typ.PkgPath(); pkgPath == "sync" || pkgPath == "sync/atomic" ||
+		buildCopierForStandardTypes(typ, typ) != nil {
+		return false
+	}
+	if ctx.CopyViaDeepCopyMethod && buildCopierForDeepCopyMethods(typ) != nil {
+		return false
+	}
+	if buildCopierForCopyFuncs(ctx, typ, typ) != nil {
+		return false
+	}
+	if copyingMethods, postCopyMethod := typeParseMethods(ctx, typ); copyingMethods != nil || postCopyMethod != nil {
+		return false
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		detail := &fieldDetail{field: &sf}
+		parseTag(detail)
+		if detail.ignored || !ctx.bulkCopyable(sf.Type) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,133 @@
+package deepcopy
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testBulkPoint struct {
+	X, Y float64
+	tag  [2]uint8
+}
+
+type testBulkShape struct {
+	ID     int64
+	Points [3]testBulkPoint
+	Active bool
+	scale  complex64
+}
+
+type testBulkPostCopy struct {
+	N int
+}
+
+func (p *testBulkPostCopy) PostCopy(src any) error {
+	p.N *= 2
+	return nil
+}
+
+func Test_Copy_bulk(t *testing.T) {
+	t.Run("#1: struct with unexported fields", func(t *testing.T) {
+		s := testBulkShape{ID: 1, Points: [3]testBulkPoint{{X: 1, Y: 2, tag: [2]uint8{3, 4}}}, Active: true, scale: 2}
+		var d testBulkShape
+		err := Copy(&d, s)
+		assert.Nil(t, err)
+		assert.Equal(t, s, d)
+	})
+
+	t.Run("#2: slices and arrays of structs", func(t *testing.T) {
+		type SS struct {
+			Points []testBulkPoint
+			points [2]testBulkPoint
+		}
+		s := &SS{
+			Points: []testBulkPoint{{X: 1}, {X: 2, tag: [2]uint8{1}}, {X: 3}},
+			points: [2]testBulkPoint{{Y: 1}, {Y: 2}},
+		}
+		var d SS
+		err := Copy(&d, s)
+		assert.Nil(t, err)
+		assert.Equal(t, *s, d)
+		d.Points[0].X = 10
+		assert.Equal(t, float64(1), s.Points[0].X)
+
+		var arr [4]testBulkPoint
+		arr[3].X = 100
+		err = Copy(&arr, s.Points)
+		assert.Nil(t, err)
+		assert.Equal(t, [4]testBulkPoint{{X: 1}, {X: 2, tag: [2]uint8{1}}, {X: 3}}, arr)
+
+		var short [1]testBulkPoint
+		err = Copy(&short, s.Points)
+		assert.Nil(t, err)
+		assert.Equal(t, [1]testBulkPoint{{X: 1}}, short)
+	})
+
+	t.Run("#3: structs requiring copying field by field", func(t *testing.T) {
+		type SS struct {
+			A int
+			B int `copy:"-"`
+		}
+		d := SS{B: 2}
+		err := Copy(&d, SS{A: 1, B: 3})
+		assert.Nil(t, err)
+		assert.Equal(t, SS{A: 1, B: 2}, d)
+
+		var d2 testBulkPostCopy
+		err = Copy(&d2, testBulkPostCopy{N: 1})
+		assert.Nil(t, err)
+		assert.Equal(t, testBulkPostCopy{N: 2}, d2)
+
+		type SS3 struct {
+			N  int
+			Mu sync.Mutex
+		}
+		s3 := &SS3{N: 1}
+		s3.Mu.Lock()
+		var d3 SS3
+		err = Copy(&d3, s3)
+		assert.Nil(t, err)
+		assert.True(t, d3.Mu.TryLock())
+	})
+
+	t.Run("#4: with options", func(t *testing.T) {
+		type Handle struct {
+			ID int
+		}
+		type SS struct {
+			A int
+			H Handle
+		}
+		var d SS
+		err := Copy(&d, SS{A: 1, H: Handle{ID: 2}}, OpaqueType[Handle](), UnsafeCopyPolicy(UnsafePolicyZero))
+		assert.Nil(t, err)
+		assert.Equal(t, SS{A: 1, H: Handle{ID: 2}}, d)
+
+		type Node struct {
+			A [2]int
+			B struct{ C [2]int }
+		}
+		var d2 Node
+		err = Copy(&d2, Node{A: [2]int{1, 2}, B: struct{ C [2]int }{C: [2]int{3, 4}}}, MaxDepth(2),
+			IgnoreDepthExceeded(true))
+		assert.Nil(t, err)
+		assert.Equal(t, Node{A: [2]int{1, 2}}, d2)
+	})
+}
+
+func Test_bulkCopyable(t *testing.T) {
+	ctx := defaultContext()
+	assert.Nil(t, ctx.prepare())
+
+	assert.True(t, ctx.bulkCopyable(typeOf[testBulkShape]()))
+	assert.True(t, ctx.bulkCopyable(typeOf[[4]int]()))
+	assert.True(t, ctx.bulkCopyable(typeOf[struct{}]()))
+	assert.False(t, ctx.bulkCopyable(typeOf[struct{ S string }]()))
+	assert.False(t, ctx.bulkCopyable(typeOf[struct{ P *int }]()))
+	assert.False(t, ctx.bulkCopyable(typeOf[[2][]int]()))
+	assert.False(t, ctx.bulkCopyable(typeOf[struct{ U uintptr }]()))
+	assert.False(t, ctx.bulkCopyable(typeOf[testBulkPostCopy]()))
+	assert.False(t, ctx.bulkCopyable(typeOf[sync.Mutex]()))
+}
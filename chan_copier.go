@@ -0,0 +1,31 @@
+package deepcopy
+
+import (
+	"reflect"
+)
+
+// chanCopier data structure of copier that copies from a `channel`
+type chanCopier struct {
+	policy ChanPolicy
+}
+
+// Copy implementation of Copy function for channel copier
+func (c *chanCopier) Copy(dst, src reflect.Value) error {
+	if src.IsNil() || c.policy == ChanPolicyNil {
+		dst.Set(reflect.Zero(dst.Type())) // NOTE: Go1.18 has no SetZero
+		return nil
+	}
+	dstType := dst.Type()
+	if c.policy == ChanPolicyShare {
+		if src.Type() == dstType {
+			dst.Set(src)
+		} else {
+			dst.Set(src.Convert(dstType))
+		}
+		return nil
+	}
+	// NOTE: only bidirectional channels can be created, then they are converted to the destination type
+	newChan := reflect.MakeChan(reflect.ChanOf(reflect.BothDir, dstType.Elem()), src.Cap())
+	dst.Set(newChan.Convert(dstType))
+	return nil
+}
@@ -0,0 +1,97 @@
+package deepcopy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Copy_chan(t *testing.T) {
+	t.Run("#1: share channels", func(t *testing.T) {
+		s := make(chan int, 10)
+		var d chan int
+		err := Copy(&d, s, ChanCopyPolicy(ChanPolicyShare))
+		assert.Nil(t, err)
+		assert.Equal(t, s, d)
+	})
+
+	t.Run("#2: share channels of directional types", func(t *testing.T) {
+		s := make(chan int, 10)
+		var d <-chan int
+		err := Copy(&d, s, ChanCopyPolicy(ChanPolicyShare))
+		assert.Nil(t, err)
+		s <- 1
+		assert.Equal(t, 1, <-d)
+	})
+
+	t.Run("#3: create new channels", func(t *testing.T) {
+		type SS struct {
+			I  int
+			C  chan int
+			RC <-chan string
+			NC chan int
+		}
+		type DD struct {
+			I  int
+			C  chan<- int
+			RC <-chan string
+			NC chan int
+		}
+		s := SS{I: 1, C: make(chan int, 10), RC: make(chan string)}
+		var d DD
+		err := Copy(&d, s, ChanCopyPolicy(ChanPolicyNew))
+		assert.Nil(t, err)
+		assert.Equal(t, 1, d.I)
+		assert.Equal(t, 10, cap(d.C))
+		assert.NotNil(t, d.RC)
+		assert.True(t, s.RC != d.RC)
+		assert.Nil(t, d.NC)
+	})
+
+	t.Run("#4: leave channels nil", func(t *testing.T) {
+		type SS struct {
+			I int
+			C chan int
+		}
+		s := SS{I: 1, C: make(chan int, 10)}
+		d := SS{C: make(chan int)}
+		err := Copy(&d, s, ChanCopyPolicy(ChanPolicyNil))
+		assert.Nil(t, err)
+		assert.Equal(t, SS{I: 1}, d)
+	})
+
+	t.Run("#5: channels within interfaces", func(t *testing.T) {
+		var s any = make(chan int, 10)
+		var d any
+		err := Copy(&d, s, ChanCopyPolicy(ChanPolicyShare))
+		assert.Nil(t, err)
+		assert.Equal(t, s, d)
+	})
+}
+
+func Test_Copy_chan_error(t *testing.T) {
+	t.Run("#1: channels are non-copyable by default", func(t *testing.T) {
+		s := make(chan int, 10)
+		var d chan int
+		err := Copy(&d, s)
+		assert.ErrorIs(t, err, ErrTypeNonCopyable)
+	})
+
+	t.Run("#2: can't share receive-only channels as bidirectional ones", func(t *testing.T) {
+		var s <-chan int = make(chan int, 10)
+		var d chan int
+		err := Copy(&d, s, ChanCopyPolicy(ChanPolicyShare))
+		assert.ErrorIs(t, err, ErrTypeNonCopyable)
+
+		err = Copy(&d, s, ChanCopyPolicy(ChanPolicyNew))
+		assert.Nil(t, err)
+		assert.Equal(t, 10, cap(d))
+	})
+
+	t.Run("#3: channels having different element types", func(t *testing.T) {
+		s := make(chan int, 10)
+		var d chan int64
+		err := Copy(&d, s, ChanCopyPolicy(ChanPolicyNew))
+		assert.ErrorIs(t, err, ErrTypeNonCopyable)
+	})
+}
@@ -0,0 +1,348 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// codeWriter writer of generated code, indentation is made when formatting the code
+type codeWriter struct {
+	bytes.Buffer
+}
+
+// line writes a formatted line of code
+func (w *codeWriter) line(format string, args ...any) {
+	fmt.Fprintf(w, format, args...)
+	w.WriteByte('\n')
+}
+
+// generateFunc generates code of the copy function
+func (g *generator) generateFunc(fn *copyFunc) error {
+	plan, err := g.plan(fn.pair)
+	if err != nil {
+		return err
+	}
+	w := &codeWriter{}
+	w.line("// %s copies %s to %s", fn.name, fn.pair.src.Obj().Name(), fn.pair.dst.Obj().Name())
+	w.line("func %s(dst *%s, src *%s) error {", fn.name, g.typeExpr(fn.pair.dst), g.typeExpr(fn.pair.src))
+	for _, fp := range plan.fields {
+		if fp.method != nil {
+			g.emitMethodCall(w, fp)
+		} else {
+			g.emitField(w, fp)
+		}
+	}
+	if plan.postCopy {
+		w.line("return dst.PostCopy(*src)")
+	} else {
+		w.line("return nil")
+	}
+	w.line("}")
+	fn.code = w.String()
+	return nil
+}
+
+// srcNilChecks returns checks of nil embedded struct pointers on the path of the source field
+func srcNilChecks(path []*types.Var) []string {
+	var checks []string
+	for i := 0; i < len(path)-1; i++ {
+		if _, ok := path[i].Type().Underlying().(*types.Pointer); ok {
+			checks = append(checks, "src."+fieldPathName(path[:i+1]))
+		}
+	}
+	return checks
+}
+
+// emitMethodCall emits code of copying the source field via the destination method
+func (g *generator) emitMethodCall(w *codeWriter, fp *fieldPlan) {
+	nilChecks := srcNilChecks(fp.src.path)
+	if len(nilChecks) > 0 {
+		// When an embedded struct pointer is nil, there's no field to copy from
+		w.line("if %s != nil {", strings.Join(nilChecks, " != nil && "))
+	}
+	w.line("if err := dst.%s(src.%s); err != nil {", fp.method.Name(), fieldPathName(fp.src.path))
+	w.line("return err")
+	w.line("}")
+	if len(nilChecks) > 0 {
+		w.line("}")
+	}
+}
+
+// emitField emits code of copying the source field to the destination field
+func (g *generator) emitField(w *codeWriter, fp *fieldPlan) {
+	dstExpr, srcExpr := "dst."+fieldPathName(fp.dst.path), "src."+fieldPathName(fp.src.path)
+	dstType := fp.dst.field.Type()
+
+	nilChecks := srcNilChecks(fp.src.path)
+	if len(nilChecks) > 0 {
+		// When an embedded struct pointer is nil, there's no field to copy from, reset the dst field to zero
+		w.line("if %s == nil {", strings.Join(nilChecks, " == nil || "))
+		dstChecks := make([]string, 0, len(fp.dst.path))
+		for i := 0; i < len(fp.dst.path)-1; i++ {
+			if _, ok := fp.dst.path[i].Type().Underlying().(*types.Pointer); ok {
+				dstChecks = append(dstChecks, "dst."+fieldPathName(fp.dst.path[:i+1])+" != nil")
+			}
+		}
+		if len(dstChecks) > 0 {
+			w.line("if %s {", strings.Join(dstChecks, " && "))
+		}
+		w.line("%s = %s", dstExpr, g.zeroExpr(dstType))
+		if len(dstChecks) > 0 {
+			w.line("}")
+		}
+		w.line("} else {")
+	}
+
+	// Embedded struct pointers on the way to the dst field are initialized
+	for i := 0; i < len(fp.dst.path)-1; i++ {
+		if ptr, ok := fp.dst.path[i].Type().Underlying().(*types.Pointer); ok {
+			embedded := "dst." + fieldPathName(fp.dst.path[:i+1])
+			w.line("if %s == nil {", embedded)
+			w.line("%s = new(%s)", embedded, g.typeExpr(ptr.Elem()))
+			w.line("}")
+		}
+	}
+
+	body := &codeWriter{}
+	mayFail := g.emitFieldCopy(body, dstExpr, srcExpr, fp)
+	if fp.dst.nilOnZero {
+		switch dstType.Underlying().(type) {
+		case *types.Slice, *types.Map:
+			body.line("if len(%s) == 0 {", dstExpr)
+			body.line("%s = nil", dstExpr)
+			body.line("}")
+		default:
+			body.line("deepcopy.SetNilOnZero(&%s)", dstExpr)
+		}
+	}
+	if mayFail && !fp.required {
+		// Errors of copying fields not required are ignored
+		w.line("_ = func() error {")
+		w.Write(body.Bytes())
+		w.line("return nil")
+		w.line("}()")
+	} else {
+		w.Write(body.Bytes())
+	}
+
+	if len(nilChecks) > 0 {
+		w.line("}")
+	}
+}
+
+// emitFieldCopy emits code of copying the field value, returns true if the copying may fail
+func (g *generator) emitFieldCopy(w *codeWriter, dstExpr, srcExpr string, fp *fieldPlan) bool {
+	if g.shallowCopied(fp) {
+		w.line("%s = %s", dstExpr, srcExpr)
+		return false
+	}
+	dstType, srcType := fp.dst.field.Type(), fp.src.field.Type()
+	if g.valueDirect(dstType, srcType, true) {
+		return g.emitCopy(w, dstExpr, srcExpr, dstType, srcType, 0)
+	}
+	w.line("if err := deepcopy.CopyValue(%s, %s); err != nil {", addr(dstExpr), addr(srcExpr))
+	w.line("return err")
+	w.line("}")
+	return true
+}
+
+// emitCopy emits code of copying the source value to the destination, returns true if the copying may fail.
+// NOTE: the values must be copied by the generated code as checked by valueDirect.
+//
+//nolint:gocyclo
+func (g *generator) emitCopy(w *codeWriter, dstExpr, srcExpr string, dstType, srcType types.Type, depth int) bool {
+	dstType, srcType = unalias(dstType), unalias(srcType)
+	dstUnder, srcUnder := dstType.Underlying(), srcType.Underlying()
+
+	if isSimple(srcType) {
+		if expr, ok := g.simpleExpr(srcExpr, dstType, srcType); ok {
+			w.line("%s = %s", dstExpr, expr)
+			return false
+		}
+	}
+
+	if src, ok := srcUnder.(*types.Pointer); ok {
+		w.line("if %s == nil {", srcExpr)
+		if dst, ok := dstUnder.(*types.Pointer); ok {
+			w.line("%s = nil", dstExpr)
+			w.line("} else {")
+			w.line("if %s == nil {", dstExpr)
+			w.line("%s = new(%s)", dstExpr, g.typeExpr(dst.Elem()))
+			w.line("}")
+			mayFail := g.emitCopy(w, deref(dstExpr), deref(srcExpr), dst.Elem(), src.Elem(), depth)
+			w.line("}")
+			return mayFail
+		}
+		w.line("%s = %s", dstExpr, g.zeroExpr(dstType))
+		w.line("} else {")
+		mayFail := g.emitCopy(w, dstExpr, deref(srcExpr), dstType, src.Elem(), depth)
+		w.line("}")
+		return mayFail
+	}
+	if dst, ok := dstUnder.(*types.Pointer); ok {
+		w.line("if %s == nil {", dstExpr)
+		w.line("%s = new(%s)", dstExpr, g.typeExpr(dst.Elem()))
+		w.line("}")
+		return g.emitCopy(w, deref(dstExpr), srcExpr, dst.Elem(), srcType, depth)
+	}
+
+	switch src := srcUnder.(type) {
+	case *types.Slice, *types.Array:
+		return g.emitCopyToSliceOrArray(w, dstExpr, srcExpr, dstType, src, depth)
+	case *types.Struct:
+		dstNamed, _ := dstType.(*types.Named)
+		srcNamed, _ := srcType.(*types.Named)
+		pair := structPair{dst: dstNamed, src: srcNamed}
+		fn := g.requestFunc(pair)
+		w.line("if err := %s(%s, %s); err != nil {", fn.name, addr(dstExpr), addr(srcExpr))
+		w.line("return err")
+		w.line("}")
+		return g.mayFail(pair)
+	case *types.Map:
+		return g.emitCopyMap(w, dstExpr, srcExpr, dstType, src, depth)
+	}
+	panic(fmt.Sprintf("unexpected copying %v -> %v", srcType, dstType))
+}
+
+// emitCopyToSliceOrArray emits code of copying the source slice or array to the destination slice or array
+func (g *generator) emitCopyToSliceOrArray(w *codeWriter, dstExpr, srcExpr string,
+	dstType, srcUnder types.Type, depth int) bool {
+	dstElem, srcElem := sliceElem(dstType.Underlying()), sliceElem(srcUnder)
+	srcArray, srcIsArray := srcUnder.(*types.Array)
+	srcSlice := !srcIsArray
+	index, suffix := "i", ""
+	if depth > 0 {
+		suffix = strconv.Itoa(depth)
+		index += suffix
+	}
+	mayFail := g.valueMayFail(dstElem, srcElem)
+
+	// Slice/Array -> Array
+	if dstArray, ok := dstType.Underlying().(*types.Array); ok {
+		w.line("for %s := range %s {", index, operand(dstExpr))
+		if srcSlice || srcArray.Len() < dstArray.Len() {
+			w.line("if %s >= len(%s) {", index, operand(srcExpr))
+			w.line("%s[%s] = %s", operand(dstExpr), index, g.zeroExpr(dstElem))
+			w.line("continue")
+			w.line("}")
+		}
+		g.emitCopy(w, operand(dstExpr)+"["+index+"]", operand(srcExpr)+"["+index+"]", dstElem, srcElem, depth+1)
+		w.line("}")
+		return mayFail
+	}
+
+	// Slice/Array -> Slice
+	if srcSlice {
+		w.line("if %s == nil {", srcExpr)
+		w.line("%s = nil", dstExpr)
+		w.line("} else {")
+	}
+	// The destination is set only when all items are copied
+	target := dstExpr
+	if mayFail {
+		target = "slice" + suffix
+		w.line("%s := make(%s, len(%s))", target, g.typeExpr(dstType), operand(srcExpr))
+	} else {
+		w.line("%s = make(%s, len(%s))", target, g.typeExpr(dstType), operand(srcExpr))
+	}
+	if types.Identical(dstElem, srcElem) && isSimple(srcElem) {
+		from := operand(srcExpr)
+		if !srcSlice {
+			from += "[:]"
+		}
+		w.line("copy(%s, %s)", operand(target), from)
+	} else {
+		w.line("for %s := range %s {", index, operand(srcExpr))
+		g.emitCopy(w, operand(target)+"["+index+"]", operand(srcExpr)+"["+index+"]", dstElem, srcElem, depth+1)
+		w.line("}")
+	}
+	if mayFail {
+		w.line("%s = %s", dstExpr, target)
+	}
+	if srcSlice {
+		w.line("}")
+	}
+	return mayFail
+}
+
+// emitCopyMap emits code of copying the source map to the destination map
+func (g *generator) emitCopyMap(w *codeWriter, dstExpr, srcExpr string,
+	dstType types.Type, src *types.Map, depth int) bool {
+	dst, _ := dstType.Underlying().(*types.Map)
+	suffix := ""
+	if depth > 0 {
+		suffix = strconv.Itoa(depth)
+	}
+	key, value := "k"+suffix, "v"+suffix
+
+	w.line("if %s == nil {", srcExpr)
+	w.line("%s = nil", dstExpr)
+	w.line("} else {")
+	w.line("if %s == nil {", dstExpr)
+	w.line("%s = make(%s, len(%s))", dstExpr, g.typeExpr(dstType), operand(srcExpr))
+	w.line("}")
+	w.line("for %s, %s := range %s {", key, value, operand(srcExpr))
+	keyExpr, keyMayFail := g.emitMapItem(w, "d"+key, key, dst.Key(), src.Key(), depth+1)
+	valueExpr, valueMayFail := g.emitMapItem(w, "d"+value, value, dst.Elem(), src.Elem(), depth+1)
+	w.line("%s[%s] = %s", operand(dstExpr), keyExpr, valueExpr)
+	w.line("}")
+	w.line("}")
+	return keyMayFail || valueMayFail
+}
+
+// emitMapItem emits code of copying the map key or value, returns expression of the copied item
+func (g *generator) emitMapItem(w *codeWriter, dstVar, srcVar string,
+	dstType, srcType types.Type, depth int) (string, bool) {
+	if isSimple(srcType) {
+		if expr, ok := g.simpleExpr(srcVar, dstType, srcType); ok {
+			return expr, false
+		}
+	}
+	w.line("var %s %s", dstVar, g.typeExpr(dstType))
+	return dstVar, g.emitCopy(w, dstVar, srcVar, dstType, srcType, depth)
+}
+
+// simpleExpr returns expression of assigning or converting the value of simple types
+func (g *generator) simpleExpr(expr string, dstType, srcType types.Type) (string, bool) {
+	if types.Identical(dstType, srcType) {
+		return expr, true
+	}
+	if !types.ConvertibleTo(srcType, dstType) {
+		return "", false
+	}
+	typeExpr := g.typeExpr(dstType)
+	if strings.HasPrefix(typeExpr, "func") || strings.HasPrefix(typeExpr, "*") || strings.HasPrefix(typeExpr, "<-") {
+		typeExpr = "(" + typeExpr + ")"
+	}
+	// Integers are converted to strings as runes, the same as the reflection does
+	srcBasic, srcOK := srcType.Underlying().(*types.Basic)
+	dstBasic, dstOK := dstType.Underlying().(*types.Basic)
+	if srcOK && dstOK && srcBasic.Info()&types.IsInteger != 0 && dstBasic.Info()&types.IsString != 0 {
+		return typeExpr + "(rune(" + expr + "))", true
+	}
+	return typeExpr + "(" + expr + ")", true
+}
+
+// zeroExpr returns expression of the zero value of the type
+func (g *generator) zeroExpr(typ types.Type) string {
+	switch t := typ.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case t.Info()&types.IsBoolean != 0:
+			return "false"
+		case t.Info()&types.IsString != 0:
+			return `""`
+		case t.Kind() == types.UnsafePointer:
+			return "nil"
+		default:
+			return "0"
+		}
+	case *types.Struct, *types.Array:
+		return g.typeExpr(typ) + "{}"
+	default:
+		return "nil"
+	}
+}
@@ -0,0 +1,404 @@
+package main
+
+import (
+	"fmt"
+	"go/types"
+	"reflect"
+	"strings"
+
+	"github.com/tiendc/go-deepcopy"
+)
+
+const (
+	methodPrefixCopy = "Copy"
+	methodPostCopy   = "PostCopy"
+)
+
+var (
+	errType = types.Universe.Lookup("error").Type()
+
+	// strictModes values of tag option `strict` of blank struct fields
+	strictModes = map[string]deepcopy.StrictMode{
+		"":     deepcopy.StrictModeAll,
+		"all":  deepcopy.StrictModeAll,
+		"src":  deepcopy.StrictModeSrc,
+		"dst":  deepcopy.StrictModeDst,
+		"none": deepcopy.StrictModeNone,
+	}
+)
+
+// fieldDetail copying detail of a struct field, the same as the one the library parses at runtime
+type fieldDetail struct {
+	field      *types.Var
+	key        string
+	ignored    bool
+	required   bool
+	nilOnZero  bool
+	shallow    bool
+	timeFormat bool
+
+	done         bool
+	path         []*types.Var
+	nestedFields []*fieldDetail
+}
+
+// markDone sets the `done` flag of a field detail and all of its nested fields recursively
+func (detail *fieldDetail) markDone() {
+	detail.done = true
+	for _, f := range detail.nestedFields {
+		f.markDone()
+	}
+}
+
+// structFields fields of a struct including direct fields and fields inherited from embedded structs
+type structFields struct {
+	directKeys     []string
+	direct         map[string]*fieldDetail
+	inheritedKeys  []string
+	inherited      map[string]*fieldDetail
+	copyingMethods map[string]*types.Func
+	postCopy       bool
+}
+
+// parseTag parses struct tag for getting copying detail
+func (g *generator) parseTag(detail *fieldDetail, tag string) {
+	tagValue, ok := reflect.StructTag(tag).Lookup(g.tagName)
+	detail.key = detail.field.Name()
+	if !ok {
+		return
+	}
+
+	tags := splitTag(tagValue)
+	switch {
+	case tags[0] == "-":
+		detail.ignored = true
+	case tags[0] != "":
+		detail.key = tags[0]
+	}
+
+	for _, tagOpt := range tags[1:] {
+		optName, _, _ := strings.Cut(tagOpt, "=")
+		switch optName {
+		case "required":
+			if !detail.ignored {
+				detail.required = true
+			}
+		case "nilonzero":
+			switch detail.field.Type().Underlying().(type) {
+			case *types.Pointer, *types.Interface, *types.Slice, *types.Map:
+				detail.nilOnZero = true
+			}
+		case "shallow":
+			detail.shallow = true
+		case "layout", "unix", "utc":
+			detail.timeFormat = true
+		}
+	}
+}
+
+// splitTag splits a tag value into the key and options separated by commas,
+// commas within single quotes such as `layout='Mon, 02 Jan 2006'` don't separate options
+func splitTag(tagValue string) []string {
+	tags := make([]string, 0, strings.Count(tagValue, ",")+1)
+	start, quoted := 0, false
+	for i := 0; i < len(tagValue); i++ {
+		switch tagValue[i] {
+		case '\'':
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				tags = append(tags, tagValue[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(tags, tagValue[start:])
+}
+
+// parseFields parses fields of the struct type and copying methods of its pointer type
+func (g *generator) parseFields(named *types.Named) *structFields {
+	st, _ := named.Underlying().(*types.Struct)
+	fields := &structFields{
+		direct:    map[string]*fieldDetail{},
+		inherited: map[string]*fieldDetail{},
+	}
+	for i := 0; i < st.NumFields(); i++ {
+		detail := &fieldDetail{field: st.Field(i), path: []*types.Var{st.Field(i)}}
+		g.parseTag(detail, st.Tag(i))
+		if detail.ignored {
+			continue
+		}
+		fields.directKeys = append(fields.directKeys, detail.key)
+		fields.direct[detail.key] = detail
+
+		// Parse embedded struct to get its fields
+		if detail.field.Embedded() {
+			nestedKeys, nested := g.parseNestedFields(detail.field.Type(), detail.path)
+			for _, key := range nestedKeys {
+				fields.inheritedKeys = append(fields.inheritedKeys, key)
+				fields.inherited[key] = nested[key]
+				detail.nestedFields = append(detail.nestedFields, nested[key])
+			}
+		}
+	}
+	fields.copyingMethods, fields.postCopy = parseMethods(named)
+	return fields
+}
+
+// parseNestedFields parses all fields of the embedded struct with the path of the embedded field
+func (g *generator) parseNestedFields(typ types.Type, path []*types.Var) ([]string, map[string]*fieldDetail) {
+	if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	st, ok := typ.Underlying().(*types.Struct)
+	if !ok {
+		return nil, nil
+	}
+	var keys []string
+	result := map[string]*fieldDetail{}
+	add := func(key string, detail *fieldDetail) {
+		if _, exists := result[key]; !exists {
+			keys = append(keys, key)
+		}
+		result[key] = detail
+	}
+
+	for i := 0; i < st.NumFields(); i++ {
+		fieldPath := append(append([]*types.Var{}, path...), st.Field(i))
+		detail := &fieldDetail{field: st.Field(i), path: fieldPath}
+		g.parseTag(detail, st.Tag(i))
+		if detail.ignored {
+			continue
+		}
+		add(detail.key, detail)
+		// Parse embedded struct recursively to get its fields
+		if detail.field.Embedded() {
+			nestedKeys, nested := g.parseNestedFields(detail.field.Type(), detail.path)
+			for _, key := range nestedKeys {
+				add(key, nested[key])
+				detail.nestedFields = append(detail.nestedFields, nested[key])
+			}
+		}
+	}
+	return keys, result
+}
+
+// parseMethods collects copying methods and checks the post-copy method of the pointer type
+func parseMethods(named *types.Named) (copyingMethods map[string]*types.Func, postCopy bool) {
+	methodSet := types.NewMethodSet(types.NewPointer(named))
+	copyingMethods = map[string]*types.Func{}
+	for i := 0; i < methodSet.Len(); i++ {
+		method, ok := methodSet.At(i).Obj().(*types.Func)
+		if !ok || !method.Exported() {
+			continue
+		}
+		sig, _ := method.Type().(*types.Signature)
+		if sig.Params().Len() != 1 || sig.Results().Len() != 1 ||
+			!types.Identical(sig.Results().At(0).Type(), errType) {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(method.Name(), methodPrefixCopy):
+			copyingMethods[method.Name()] = method
+		case method.Name() == methodPostCopy:
+			iface, ok := unalias(sig.Params().At(0).Type()).(*types.Interface)
+			postCopy = ok && iface.Empty()
+		}
+	}
+	return copyingMethods, postCopy
+}
+
+// fieldPlan plan of copying a source field to a destination field or via a destination method
+type fieldPlan struct {
+	src      *fieldDetail
+	dst      *fieldDetail
+	method   *types.Func
+	required bool
+}
+
+// pairPlan plan of copying a source struct to a destination struct
+type pairPlan struct {
+	fields   []*fieldPlan
+	postCopy bool
+}
+
+// plan makes the plan of copying the struct pair following the way the library does at runtime,
+// returns error when the copying always fails or the generated code can't do the same
+//
+//nolint:gocognit,gocyclo
+func (g *generator) plan(pair structPair) (*pairPlan, error) {
+	if plan := g.plans[pair]; plan != nil {
+		return plan, nil
+	}
+	dstFields, srcFields := g.parseFields(pair.dst), g.parseFields(pair.src)
+	plan := &pairPlan{postCopy: dstFields.postCopy}
+
+	for _, key := range append(append([]string{}, srcFields.directKeys...), srcFields.inheritedKeys...) {
+		// Find field details from `src` having the key
+		sfDetail := srcFields.direct[key]
+		if sfDetail == nil {
+			sfDetail = srcFields.inherited[key]
+		}
+		if sfDetail == nil || sfDetail.ignored || sfDetail.done {
+			continue
+		}
+
+		// Copying methods have higher priority, so if a method defined in the dst struct, use it
+		methodName := methodPrefixCopy + strings.ToUpper(key[:1]) + key[1:]
+		if method, exists := dstFields.copyingMethods[methodName]; exists {
+			if !g.accessible(sfDetail.path) {
+				return nil, fmt.Errorf("%w: struct field '%v[%s]' is inaccessible",
+					errTypeUnsupported, pair.src, fieldPathName(sfDetail.path))
+			}
+			sig, _ := method.Type().(*types.Signature)
+			paramType := sig.Params().At(0).Type()
+			if !types.AssignableTo(paramType, sfDetail.field.Type()) {
+				return nil, fmt.Errorf("%w: struct method '%v.%s' does not accept argument type '%v' from '%v[%s]'",
+					deepcopy.ErrMethodInvalid, pair.dst, methodName, sfDetail.field.Type(),
+					pair.src, sfDetail.field.Name())
+			}
+			if !types.AssignableTo(sfDetail.field.Type(), paramType) || sig.Variadic() {
+				return nil, fmt.Errorf("%w: struct method '%v.%s' can't be called with '%v[%s]'",
+					errTypeUnsupported, pair.dst, methodName, pair.src, sfDetail.field.Name())
+			}
+			plan.fields = append(plan.fields, &fieldPlan{src: sfDetail, method: method,
+				required: sfDetail.required || sfDetail.field.Exported()})
+			sfDetail.markDone()
+			continue
+		}
+
+		// Find field details from `dst` having the key
+		dfDetail := dstFields.direct[key]
+		if dfDetail == nil {
+			dfDetail = dstFields.inherited[key]
+		}
+		if dfDetail == nil || dfDetail.ignored || dfDetail.done {
+			// Found no corresponding dest field to copy to, raise an error in case this is required
+			if sfDetail.required {
+				return nil, fmt.Errorf("%w: struct field '%v[%s]' requires copying",
+					deepcopy.ErrFieldRequireCopying, pair.src, sfDetail.field.Name())
+			}
+			continue
+		}
+		// Blank fields can't be accessed, only the ones having no data can be skipped
+		if sfDetail.field.Name() == "_" || dfDetail.field.Name() == "_" {
+			if !isEmptyStruct(sfDetail.field.Type()) || !isEmptyStruct(dfDetail.field.Type()) {
+				return nil, fmt.Errorf("%w: blank struct field '%v[%s]' or '%v[%s]' can't be copied",
+					errTypeUnsupported, pair.src, fieldPathName(sfDetail.path), pair.dst, fieldPathName(dfDetail.path))
+			}
+			dfDetail.markDone()
+			sfDetail.markDone()
+			continue
+		}
+		if !g.accessible(sfDetail.path) || !g.accessible(dfDetail.path) {
+			return nil, fmt.Errorf("%w: struct field '%v[%s]' or '%v[%s]' is inaccessible", errTypeUnsupported,
+				pair.src, fieldPathName(sfDetail.path), pair.dst, fieldPathName(dfDetail.path))
+		}
+		if sfDetail.timeFormat || dfDetail.timeFormat {
+			return nil, fmt.Errorf("%w: time format of struct field '%v[%s]' or '%v[%s]'",
+				errTypeUnsupported, pair.src, sfDetail.field.Name(), pair.dst, dfDetail.field.Name())
+		}
+
+		plan.fields = append(plan.fields, &fieldPlan{src: sfDetail, dst: dfDetail,
+			required: sfDetail.required || dfDetail.required || dfDetail.field.Exported()})
+		dfDetail.markDone()
+		sfDetail.markDone()
+	}
+
+	// Remaining dst fields can't be copied
+	for _, fields := range []map[string]*fieldDetail{dstFields.direct, dstFields.inherited} {
+		for _, dfDetail := range fields {
+			if !dfDetail.done && dfDetail.required {
+				return nil, fmt.Errorf("%w: struct field '%v[%s]' requires copying",
+					deepcopy.ErrFieldRequireCopying, pair.dst, dfDetail.field.Name())
+			}
+		}
+	}
+
+	// Strict matching set by struct tags requires all exported fields to be copied
+	strictMode := deepcopy.StrictModeNone
+	if mode, found := g.parseStrictMode(pair.src); found {
+		strictMode = mode
+	}
+	if mode, found := g.parseStrictMode(pair.dst); found {
+		strictMode = mode
+	}
+	var unmatchedFields []string
+	if strictMode&deepcopy.StrictModeSrc > 0 {
+		unmatchedFields = srcFields.appendUnmatched(unmatchedFields, "source", pair.src)
+	}
+	if strictMode&deepcopy.StrictModeDst > 0 {
+		unmatchedFields = dstFields.appendUnmatched(unmatchedFields, "destination", pair.dst)
+	}
+	if len(unmatchedFields) > 0 {
+		return nil, fmt.Errorf("%w: %s", deepcopy.ErrFieldUnmatched, strings.Join(unmatchedFields, ", "))
+	}
+
+	g.plans[pair] = plan
+	return plan, nil
+}
+
+// parseStrictMode parses strict mode of the struct set via tag option `strict` of a blank field
+func (g *generator) parseStrictMode(named *types.Named) (mode deepcopy.StrictMode, found bool) {
+	st, _ := named.Underlying().(*types.Struct)
+	for i := 0; i < st.NumFields(); i++ {
+		if st.Field(i).Name() != "_" {
+			continue
+		}
+		tagValue, ok := reflect.StructTag(st.Tag(i)).Lookup(g.tagName)
+		if !ok {
+			continue
+		}
+		for _, tagOpt := range strings.Split(tagValue, ",")[1:] {
+			optName, optValue, _ := strings.Cut(tagOpt, "=")
+			if optName != "strict" {
+				continue
+			}
+			if mode, found = strictModes[optValue]; !found {
+				mode, found = deepcopy.StrictModeAll, true
+			}
+		}
+	}
+	return mode, found
+}
+
+// appendUnmatched appends descriptions of the exported fields not copied, the same as the library does
+func (fields *structFields) appendUnmatched(unmatchedFields []string, side string, named *types.Named) []string {
+	for _, key := range append(append([]string{}, fields.directKeys...), fields.inheritedKeys...) {
+		detail := fields.direct[key]
+		if detail == nil {
+			detail = fields.inherited[key]
+		}
+		if detail.done || !detail.field.Exported() || len(detail.nestedFields) > 0 {
+			continue
+		}
+		detail.done = true
+		unmatchedFields = append(unmatchedFields, fmt.Sprintf("%s field '%v[%s]'", side, named, detail.field.Name()))
+	}
+	return unmatchedFields
+}
+
+// isEmptyStruct checks if the type is a struct type having no fields
+func isEmptyStruct(typ types.Type) bool {
+	st, ok := typ.Underlying().(*types.Struct)
+	return ok && st.NumFields() == 0
+}
+
+// accessible checks if the field path can be accessed by the generated code
+func (g *generator) accessible(path []*types.Var) bool {
+	for _, field := range path {
+		if !field.Exported() && field.Pkg() != g.pkg {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldPathName returns name of the field path such as `Base.ID`
+func fieldPathName(path []*types.Var) string {
+	names := make([]string, 0, len(path))
+	for _, field := range path {
+		names = append(names, field.Name())
+	}
+	return strings.Join(names, ".")
+}
@@ -0,0 +1,318 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	libImportPath     = "github.com/tiendc/go-deepcopy"
+	gentestImportPath = "github.com/tiendc/go-deepcopy/gentest"
+)
+
+var (
+	errTypeNotFound    = errors.New("type not found")
+	errTypeUnsupported = errors.New("type unsupported")
+)
+
+// generator generates copy functions for struct types of a package
+type generator struct {
+	pkg     *types.Package
+	tagName string
+
+	// imports names of packages imported by the generated code
+	imports map[string]string
+
+	// funcs generated copy functions in order of generation
+	funcs   []*copyFunc
+	funcMap map[structPair]*copyFunc
+	// queue pairs used by generated functions waiting for generation
+	queue []structPair
+
+	// plans cache of field copying plans of struct pairs
+	plans map[structPair]*pairPlan
+	// pairOKs cache of checking struct pairs can be copied by generated functions
+	pairOKs    map[structPair]bool
+	pairOKKeys []structPair
+	// mayFails cache of checking generated functions may return errors
+	mayFails map[structPair]bool
+}
+
+// structPair a pair of destination and source named struct types
+type structPair struct {
+	dst *types.Named
+	src *types.Named
+}
+
+// copyFunc a generated copy function
+type copyFunc struct {
+	name string
+	pair structPair
+	code string
+}
+
+// generate loads the package and generates code of copy functions and their tests
+func generate(cfg *config) (code, testCode []byte, err error) {
+	pkg, err := loadPackage(cfg.dir, cfg.output)
+	if err != nil {
+		return nil, nil, err
+	}
+	g := &generator{
+		pkg:      pkg,
+		tagName:  cfg.tagName,
+		imports:  map[string]string{},
+		funcMap:  map[structPair]*copyFunc{},
+		plans:    map[structPair]*pairPlan{},
+		pairOKs:  map[structPair]bool{},
+		mayFails: map[structPair]bool{},
+	}
+
+	for _, p := range cfg.pairs {
+		pair, err := g.lookupPair(p)
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, err = g.plan(pair); err != nil {
+			return nil, nil, err
+		}
+		if g.isValuerToScanner(pair.dst, pair.src) {
+			return nil, nil, fmt.Errorf("%w: %s -> %s is copied via driver.Valuer and sql.Scanner",
+				errTypeUnsupported, p.src, p.dst)
+		}
+		g.requestFunc(pair)
+	}
+	for len(g.queue) > 0 {
+		pair := g.queue[0]
+		g.queue = g.queue[1:]
+		if err = g.generateFunc(g.funcMap[pair]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if code, err = g.fileCode(); err != nil {
+		return nil, nil, err
+	}
+	if cfg.test {
+		if testCode, err = g.testFileCode(); err != nil {
+			return nil, nil, err
+		}
+	}
+	return code, testCode, nil
+}
+
+// loadPackage parses and type-checks the package in the directory excluding the generated file
+func loadPackage(dir, output string) (*types.Package, error) {
+	buildPkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	files := make([]*ast.File, 0, len(buildPkg.GoFiles))
+	for _, name := range buildPkg.GoFiles {
+		if name == output {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	return conf.Check(buildPkg.ImportPath, fset, files, nil)
+}
+
+// lookupPair finds the named struct types of the pair in the package
+func (g *generator) lookupPair(p typePair) (structPair, error) {
+	dst, err := g.lookupStruct(p.dst)
+	if err != nil {
+		return structPair{}, err
+	}
+	src, err := g.lookupStruct(p.src)
+	if err != nil {
+		return structPair{}, err
+	}
+	return structPair{dst: dst, src: src}, nil
+}
+
+// lookupStruct finds the named non-generic struct type in the package
+func (g *generator) lookupStruct(name string) (*types.Named, error) {
+	obj, ok := g.pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errTypeNotFound, name)
+	}
+	named, ok := unalias(obj.Type()).(*types.Named)
+	if !ok || !g.isLocalStruct(named) {
+		return nil, fmt.Errorf("%w: %s is not a non-generic struct type of the package", errTypeUnsupported, name)
+	}
+	return named, nil
+}
+
+// requestFunc returns the copy function of the pair, queues it for generation if it's new
+func (g *generator) requestFunc(pair structPair) *copyFunc {
+	if fn := g.funcMap[pair]; fn != nil {
+		return fn
+	}
+	name := "deepCopy" + pair.dst.Obj().Name()
+	if pair.dst != pair.src {
+		name += "From" + pair.src.Obj().Name()
+	}
+	fn := &copyFunc{name: name, pair: pair}
+	g.funcMap[pair] = fn
+	g.funcs = append(g.funcs, fn)
+	g.queue = append(g.queue, pair)
+	return fn
+}
+
+// fileCode returns code of the file containing all generated functions
+func (g *generator) fileCode() ([]byte, error) {
+	var body bytes.Buffer
+	body.WriteString("func init() {\n")
+	for _, fn := range g.funcs {
+		fmt.Fprintf(&body, "deepcopy.RegisterCopyFunc(%s)\n", fn.name)
+	}
+	body.WriteString("}\n")
+	for _, fn := range g.funcs {
+		body.WriteString("\n")
+		body.WriteString(fn.code)
+	}
+
+	g.imports[libImportPath] = "deepcopy"
+	return g.formatFile(g.imports, body.Bytes())
+}
+
+// testFileCode returns code of the file containing tests of all generated functions
+func (g *generator) testFileCode() ([]byte, error) {
+	var body bytes.Buffer
+	for i, fn := range g.funcs {
+		if i > 0 {
+			body.WriteString("\n")
+		}
+		fmt.Fprintf(&body, "func Test_%s(t *testing.T) {\ngentest.VerifyCopyFunc(t, %s)\n}\n", fn.name, fn.name)
+	}
+	imports := map[string]string{"testing": "testing", gentestImportPath: "gentest"}
+	return g.formatFile(imports, body.Bytes())
+}
+
+// formatFile formats the file of the package with the imports and the body
+func (g *generator) formatFile(imports map[string]string, body []byte) ([]byte, error) {
+	paths := make([]string, 0, len(imports))
+	for path := range imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by deepcopy-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", g.pkg.Name())
+	buf.WriteString("import (\n")
+	// Standard packages go first
+	for _, std := range []bool{true, false} {
+		for _, path := range paths {
+			if isStandardPackage(path) != std {
+				continue
+			}
+			if name := imports[path]; name != pathBase(path) {
+				fmt.Fprintf(&buf, "%s %s\n", name, strconv.Quote(path))
+			} else {
+				fmt.Fprintf(&buf, "%s\n", strconv.Quote(path))
+			}
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString(")\n\n")
+	buf.Write(body)
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w\n%s", err, buf.String())
+	}
+	return code, nil
+}
+
+// qualifier returns name of the package to use in the generated code, records the import if needed
+func (g *generator) qualifier(pkg *types.Package) string {
+	if pkg == g.pkg {
+		return ""
+	}
+	if name, ok := g.imports[pkg.Path()]; ok {
+		return name
+	}
+	name := pkg.Name()
+	for i := 2; g.importNameUsed(name); i++ {
+		name = pkg.Name() + strconv.Itoa(i)
+	}
+	g.imports[pkg.Path()] = name
+	return name
+}
+
+// importNameUsed checks if the name is used by an imported package
+func (g *generator) importNameUsed(name string) bool {
+	if name == "deepcopy" {
+		return true
+	}
+	for _, used := range g.imports {
+		if used == name {
+			return true
+		}
+	}
+	return false
+}
+
+// typeExpr returns expression of the type in the generated code
+func (g *generator) typeExpr(typ types.Type) string {
+	return types.TypeString(typ, g.qualifier)
+}
+
+// isStandardPackage checks if the import path is of a standard package
+func isStandardPackage(path string) bool {
+	return !strings.Contains(strings.Split(path, "/")[0], ".")
+}
+
+// pathBase returns the last element of the import path
+func pathBase(path string) string {
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+// unalias returns the actual type of the type alias.
+// NOTE: Go1.22+ can represent type aliases as types, however Go1.18 has no types.Unalias.
+func unalias(typ types.Type) types.Type {
+	for {
+		alias, ok := typ.(interface{ Rhs() types.Type })
+		if !ok {
+			return typ
+		}
+		typ = alias.Rhs()
+	}
+}
+
+// isLocalStruct checks if the type is a non-generic struct type declared in the package
+func (g *generator) isLocalStruct(named *types.Named) bool {
+	if named.Obj().Pkg() != g.pkg || named.TypeParams().Len() > 0 || named.TypeArgs().Len() > 0 {
+		return false
+	}
+	_, ok := named.Underlying().(*types.Struct)
+	return ok
+}
+
+// isForeign checks if the type is a named type declared in another package or a generic one,
+// values of these types are copied via the library
+func (g *generator) isForeign(typ types.Type) bool {
+	named, ok := unalias(typ).(*types.Named)
+	if !ok {
+		return false
+	}
+	return named.Obj().Pkg() != g.pkg || named.TypeArgs().Len() > 0
+}
@@ -0,0 +1,323 @@
+// Code generated by deepcopy-gen. DO NOT EDIT.
+
+package example
+
+import (
+	"time"
+
+	deepcopy "github.com/tiendc/go-deepcopy"
+)
+
+func init() {
+	deepcopy.RegisterCopyFunc(deepCopyUser)
+	deepcopy.RegisterCopyFunc(deepCopyUserDTOFromUser)
+	deepcopy.RegisterCopyFunc(deepCopyEventViewFromEvent)
+	deepcopy.RegisterCopyFunc(deepCopyNode)
+	deepcopy.RegisterCopyFunc(deepCopyBase)
+	deepcopy.RegisterCopyFunc(deepCopyAddress)
+	deepcopy.RegisterCopyFunc(deepCopyAddressDTOFromAddress)
+	deepcopy.RegisterCopyFunc(deepCopyAttr)
+}
+
+// deepCopyUser copies User to User
+func deepCopyUser(dst *User, src *User) error {
+	if err := deepCopyBase(&dst.Base, &src.Base); err != nil {
+		return err
+	}
+	dst.Name = src.Name
+	dst.Email = src.Email
+	dst.Age = src.Age
+	dst.Score = src.Score
+	dst.Status = src.Status
+	if src.Tags == nil {
+		dst.Tags = nil
+	} else {
+		dst.Tags = make([]string, len(src.Tags))
+		copy(dst.Tags, src.Tags)
+	}
+	if src.Roles == nil {
+		dst.Roles = nil
+	} else {
+		if dst.Roles == nil {
+			dst.Roles = make(map[string]bool, len(src.Roles))
+		}
+		for k, v := range src.Roles {
+			dst.Roles[k] = v
+		}
+	}
+	if src.Address == nil {
+		dst.Address = nil
+	} else {
+		if dst.Address == nil {
+			dst.Address = new(Address)
+		}
+		if err := deepCopyAddress(dst.Address, src.Address); err != nil {
+			return err
+		}
+	}
+	if src.Addresses == nil {
+		dst.Addresses = nil
+	} else {
+		dst.Addresses = make([]Address, len(src.Addresses))
+		for i := range src.Addresses {
+			if err := deepCopyAddress(&dst.Addresses[i], &src.Addresses[i]); err != nil {
+				return err
+			}
+		}
+	}
+	if src.Contacts == nil {
+		dst.Contacts = nil
+	} else {
+		if dst.Contacts == nil {
+			dst.Contacts = make(map[string]*Address, len(src.Contacts))
+		}
+		for k, v := range src.Contacts {
+			var dv *Address
+			if v == nil {
+				dv = nil
+			} else {
+				if dv == nil {
+					dv = new(Address)
+				}
+				if err := deepCopyAddress(dv, v); err != nil {
+					return err
+				}
+			}
+			dst.Contacts[k] = dv
+		}
+	}
+	for i := range dst.Matrix {
+		for i1 := range dst.Matrix[i] {
+			dst.Matrix[i][i1] = src.Matrix[i][i1]
+		}
+	}
+	for i := range dst.Labels {
+		dst.Labels[i] = src.Labels[i]
+	}
+	if src.Codes == nil {
+		dst.Codes = nil
+	} else {
+		dst.Codes = make([]int32, len(src.Codes))
+		copy(dst.Codes, src.Codes)
+	}
+	if src.Nickname == nil {
+		dst.Nickname = nil
+	} else {
+		if dst.Nickname == nil {
+			dst.Nickname = new(string)
+		}
+		*dst.Nickname = *src.Nickname
+	}
+	if err := deepcopy.CopyValue(&dst.Meta, &src.Meta); err != nil {
+		return err
+	}
+	dst.note = src.note
+	return nil
+}
+
+// deepCopyUserDTOFromUser copies User to UserDTO
+func deepCopyUserDTOFromUser(dst *UserDTO, src *User) error {
+	if dst.Base == nil {
+		dst.Base = new(Base)
+	}
+	if err := deepCopyBase(dst.Base, &src.Base); err != nil {
+		return err
+	}
+	dst.Name = src.Name
+	dst.Mail = src.Email
+	if err := dst.CopyAge(src.Age); err != nil {
+		return err
+	}
+	if dst.Score == nil {
+		dst.Score = new(float64)
+	}
+	*dst.Score = src.Score
+	dst.Status = int(src.Status)
+	if src.Tags == nil {
+		dst.Tags = nil
+	} else {
+		dst.Tags = make([]string, len(src.Tags))
+		copy(dst.Tags, src.Tags)
+	}
+	if len(dst.Tags) == 0 {
+		dst.Tags = nil
+	}
+	if src.Roles == nil {
+		dst.Roles = nil
+	} else {
+		if dst.Roles == nil {
+			dst.Roles = make(map[string]bool, len(src.Roles))
+		}
+		for k, v := range src.Roles {
+			dst.Roles[k] = v
+		}
+	}
+	if src.Address == nil {
+		dst.Address = AddressDTO{}
+	} else {
+		if err := deepCopyAddressDTOFromAddress(&dst.Address, src.Address); err != nil {
+			return err
+		}
+	}
+	if src.Addresses == nil {
+		dst.Addresses = nil
+	} else {
+		dst.Addresses = make([]*AddressDTO, len(src.Addresses))
+		for i := range src.Addresses {
+			if dst.Addresses[i] == nil {
+				dst.Addresses[i] = new(AddressDTO)
+			}
+			if err := deepCopyAddressDTOFromAddress(dst.Addresses[i], &src.Addresses[i]); err != nil {
+				return err
+			}
+		}
+	}
+	if src.Contacts == nil {
+		dst.Contacts = nil
+	} else {
+		if dst.Contacts == nil {
+			dst.Contacts = make(map[string]AddressDTO, len(src.Contacts))
+		}
+		for k, v := range src.Contacts {
+			var dv AddressDTO
+			if v == nil {
+				dv = AddressDTO{}
+			} else {
+				if err := deepCopyAddressDTOFromAddress(&dv, v); err != nil {
+					return err
+				}
+			}
+			dst.Contacts[k] = dv
+		}
+	}
+	for i := range dst.Matrix {
+		for i1 := range dst.Matrix[i] {
+			dst.Matrix[i][i1] = src.Matrix[i][i1]
+		}
+	}
+	dst.Labels = make([]string, len(src.Labels))
+	copy(dst.Labels, src.Labels[:])
+	if src.Codes == nil {
+		dst.Codes = nil
+	} else {
+		dst.Codes = make([]int64, len(src.Codes))
+		for i := range src.Codes {
+			dst.Codes[i] = int64(src.Codes[i])
+		}
+	}
+	if src.Nickname == nil {
+		dst.Nickname = ""
+	} else {
+		dst.Nickname = *src.Nickname
+	}
+	if err := deepcopy.CopyValue(&dst.Meta, &src.Meta); err != nil {
+		return err
+	}
+	dst.note = src.note
+	return dst.PostCopy(*src)
+}
+
+// deepCopyEventViewFromEvent copies Event to EventView
+func deepCopyEventViewFromEvent(dst *EventView, src *Event) error {
+	dst.Title = src.Title
+	if src.Dates == nil {
+		dst.Dates = nil
+	} else {
+		dst.Dates = make([]time.Time, len(src.Dates))
+		copy(dst.Dates, src.Dates)
+	}
+	if src.Base == nil {
+		dst.ID = 0
+	} else {
+		dst.ID = src.Base.ID
+	}
+	if src.Base == nil {
+		dst.CreatedAt = time.Time{}
+	} else {
+		dst.CreatedAt = src.Base.CreatedAt
+	}
+	return nil
+}
+
+// deepCopyNode copies Node to Node
+func deepCopyNode(dst *Node, src *Node) error {
+	dst.Value = src.Value
+	if src.Children == nil {
+		dst.Children = nil
+	} else {
+		slice := make([]*Node, len(src.Children))
+		for i := range src.Children {
+			if src.Children[i] == nil {
+				slice[i] = nil
+			} else {
+				if slice[i] == nil {
+					slice[i] = new(Node)
+				}
+				if err := deepCopyNode(slice[i], src.Children[i]); err != nil {
+					return err
+				}
+			}
+		}
+		dst.Children = slice
+	}
+	if src.Attrs == nil {
+		dst.Attrs = nil
+	} else {
+		if dst.Attrs == nil {
+			dst.Attrs = make(map[string]Attr, len(src.Attrs))
+		}
+		for k, v := range src.Attrs {
+			var dv Attr
+			if err := deepCopyAttr(&dv, &v); err != nil {
+				return err
+			}
+			dst.Attrs[k] = dv
+		}
+	}
+	if src.Weights == nil {
+		dst.Weights = nil
+	} else {
+		if dst.Weights == nil {
+			dst.Weights = make(map[string][]float32, len(src.Weights))
+		}
+		for k, v := range src.Weights {
+			var dv []float32
+			if v == nil {
+				dv = nil
+			} else {
+				dv = make([]float32, len(v))
+				copy(dv, v)
+			}
+			dst.Weights[k] = dv
+		}
+	}
+	return nil
+}
+
+// deepCopyBase copies Base to Base
+func deepCopyBase(dst *Base, src *Base) error {
+	dst.ID = src.ID
+	dst.CreatedAt = src.CreatedAt
+	return nil
+}
+
+// deepCopyAddress copies Address to Address
+func deepCopyAddress(dst *Address, src *Address) error {
+	dst.Street = src.Street
+	dst.City = src.City
+	return nil
+}
+
+// deepCopyAddressDTOFromAddress copies Address to AddressDTO
+func deepCopyAddressDTOFromAddress(dst *AddressDTO, src *Address) error {
+	dst.Street = src.Street
+	dst.City = src.City
+	return nil
+}
+
+// deepCopyAttr copies Attr to Attr
+func deepCopyAttr(dst *Attr, src *Attr) error {
+	dst.Key = src.Key
+	dst.Value = src.Value
+	return nil
+}
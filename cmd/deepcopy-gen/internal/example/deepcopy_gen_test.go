@@ -0,0 +1,41 @@
+// Code generated by deepcopy-gen. DO NOT EDIT.
+
+package example
+
+import (
+	"testing"
+
+	"github.com/tiendc/go-deepcopy/gentest"
+)
+
+func Test_deepCopyUser(t *testing.T) {
+	gentest.VerifyCopyFunc(t, deepCopyUser)
+}
+
+func Test_deepCopyUserDTOFromUser(t *testing.T) {
+	gentest.VerifyCopyFunc(t, deepCopyUserDTOFromUser)
+}
+
+func Test_deepCopyEventViewFromEvent(t *testing.T) {
+	gentest.VerifyCopyFunc(t, deepCopyEventViewFromEvent)
+}
+
+func Test_deepCopyNode(t *testing.T) {
+	gentest.VerifyCopyFunc(t, deepCopyNode)
+}
+
+func Test_deepCopyBase(t *testing.T) {
+	gentest.VerifyCopyFunc(t, deepCopyBase)
+}
+
+func Test_deepCopyAddress(t *testing.T) {
+	gentest.VerifyCopyFunc(t, deepCopyAddress)
+}
+
+func Test_deepCopyAddressDTOFromAddress(t *testing.T) {
+	gentest.VerifyCopyFunc(t, deepCopyAddressDTOFromAddress)
+}
+
+func Test_deepCopyAttr(t *testing.T) {
+	gentest.VerifyCopyFunc(t, deepCopyAttr)
+}
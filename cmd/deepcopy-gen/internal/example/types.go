@@ -0,0 +1,129 @@
+// Package example contains types for demonstrating and testing copy functions generated by deepcopy-gen.
+package example
+
+import (
+	"errors"
+	"time"
+)
+
+//go:generate go run github.com/tiendc/go-deepcopy/cmd/deepcopy-gen -pairs=User,UserDTO:User,EventView:Event,Node -test
+
+var (
+	errAgeInvalid = errors.New("age must not be negative")
+)
+
+// Status status of users
+type Status int
+
+// Base base fields of entities
+type Base struct {
+	ID        int
+	CreatedAt time.Time
+}
+
+// Address address of users
+type Address struct {
+	Street string
+	City   string
+}
+
+// AddressDTO address of users for transferring
+type AddressDTO struct {
+	Street string
+	City   string `copy:",required"`
+}
+
+// User user entity
+type User struct {
+	Base
+	Name      string
+	Email     string `copy:"email"`
+	Age       int
+	Score     float64
+	Status    Status
+	Tags      []string
+	Roles     map[string]bool
+	Address   *Address
+	Addresses []Address
+	Contacts  map[string]*Address
+	Matrix    [2][3]int
+	Labels    [3]string
+	Codes     []int32
+	Nickname  *string
+	Meta      any
+	Password  string `copy:"-"`
+	note      string
+}
+
+// UserDTO user for transferring
+type UserDTO struct {
+	*Base
+	Name      string
+	Mail      string `copy:"email"`
+	Age       int64
+	Score     *float64
+	Status    int
+	Tags      []string `copy:",nilonzero"`
+	Roles     map[string]bool
+	Address   AddressDTO
+	Addresses []*AddressDTO
+	Contacts  map[string]AddressDTO
+	Matrix    [2][3]int
+	Labels    []string
+	Codes     []int64
+	Nickname  string
+	Meta      any
+	Password  string
+	note      string
+
+	adult bool
+}
+
+// CopyAge copies user age and checks if the user is adult
+func (u *UserDTO) CopyAge(age int) error {
+	if age < 0 {
+		return errAgeInvalid
+	}
+	u.Age = int64(age)
+	u.adult = age >= 18 //nolint:mnd
+	return nil
+}
+
+// PostCopy is called after copying
+func (u *UserDTO) PostCopy(src any) error {
+	if user, ok := src.(User); ok && user.Name == "" {
+		u.Name = "anonymous"
+	}
+	return nil
+}
+
+// Event event entity with optional base fields
+type Event struct {
+	*Base
+	Title string
+	Dates []time.Time
+}
+
+// EventView flattened view of events, all fields of events must be copied
+type EventView struct {
+	_         struct{} `copy:",strict"`
+	ID        int
+	CreatedAt time.Time
+	Title     string
+	Dates     []time.Time
+}
+
+// Attr attribute of nodes
+type Attr struct {
+	Key   string
+	Value float64
+}
+
+// Node node of a tree
+type Node struct {
+	Value    int
+	Children []*Node
+	Parent   *Node `copy:"-"`
+	Attrs    map[string]Attr
+	Weights  map[string][]float32
+}
@@ -0,0 +1,112 @@
+// Command deepcopy-gen generates plain Go functions copying struct types the same way as `deepcopy.Copy`
+// does with the default settings. The generated functions are registered via `deepcopy.RegisterCopyFunc`,
+// so `deepcopy.Copy` uses them automatically.
+//
+// Usage with `go generate`:
+//
+//	//go:generate go run github.com/tiendc/go-deepcopy/cmd/deepcopy-gen -pairs=User,UserDTO:User
+//
+// Each pair is given as `Dst:Src` or just `Type` for copying a type to itself.
+// Use flag `-test` to also generate tests verifying the generated functions give the same results
+// as the reflective copying.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tiendc/go-deepcopy"
+)
+
+var (
+	errPairsInvalid = errors.New("invalid pairs")
+)
+
+// config configuration of the generator
+type config struct {
+	dir     string
+	pairs   []typePair
+	output  string
+	test    bool
+	tagName string
+}
+
+// typePair names of a destination type and a source type to generate copy function for
+type typePair struct {
+	dst string
+	src string
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("deepcopy-gen: ")
+
+	var (
+		cfg   config
+		pairs string
+	)
+	flag.StringVar(&pairs, "pairs", "", "comma-separated list of type pairs `Dst:Src` or `Type` to generate for")
+	flag.StringVar(&cfg.output, "output", "deepcopy_gen.go", "name of the output file")
+	flag.BoolVar(&cfg.test, "test", false, "also generate tests verifying the generated functions")
+	flag.StringVar(&cfg.tagName, "tag", deepcopy.DefaultTagName, "name of struct tag to parse")
+	flag.StringVar(&cfg.dir, "dir", ".", "directory of the package")
+	flag.Parse()
+
+	var err error
+	if cfg.pairs, err = parsePairs(pairs); err != nil {
+		log.Fatal(err)
+	}
+	if err = run(&cfg); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parsePairs parses the list of type pairs in form `Dst:Src,Type,...`
+func parsePairs(s string) ([]typePair, error) {
+	var pairs []typePair
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		dst, src, found := strings.Cut(item, ":")
+		if !found {
+			src = dst
+		}
+		dst, src = strings.TrimSpace(dst), strings.TrimSpace(src)
+		if dst == "" || src == "" {
+			return nil, fmt.Errorf("%w: '%s'", errPairsInvalid, item)
+		}
+		pairs = append(pairs, typePair{dst: dst, src: src})
+	}
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("%w: no type pairs given", errPairsInvalid)
+	}
+	return pairs, nil
+}
+
+// run generates the code and writes it to the output files
+func run(cfg *config) error {
+	code, testCode, err := generate(cfg)
+	if err != nil {
+		return err
+	}
+	output := filepath.Join(cfg.dir, cfg.output)
+	if err = os.WriteFile(output, code, 0o644); err != nil { //nolint:gosec,mnd
+		return err
+	}
+	if cfg.test {
+		return os.WriteFile(testFileName(output), testCode, 0o644) //nolint:gosec,mnd
+	}
+	return nil
+}
+
+// testFileName returns name of the test file for the output file
+func testFileName(output string) string {
+	return strings.TrimSuffix(output, ".go") + "_test.go"
+}
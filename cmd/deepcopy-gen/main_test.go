@@ -0,0 +1,96 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tiendc/go-deepcopy"
+)
+
+const (
+	exampleDir = "internal/example"
+)
+
+// examplePairs returns type pairs given in the `go:generate` directive of the example package
+func examplePairs(t *testing.T) string {
+	code, err := os.ReadFile(filepath.Join(exampleDir, "types.go"))
+	assert.Nil(t, err)
+	match := regexp.MustCompile(`//go:generate .* -pairs=(\S+)`).FindSubmatch(code)
+	assert.NotNil(t, match)
+	return string(match[1])
+}
+
+func Test_parsePairs(t *testing.T) {
+	t.Run("#1: valid pairs", func(t *testing.T) {
+		pairs, err := parsePairs("A, B:C ,D:D,")
+		assert.Nil(t, err)
+		assert.Equal(t, []typePair{{dst: "A", src: "A"}, {dst: "B", src: "C"}, {dst: "D", src: "D"}}, pairs)
+	})
+
+	t.Run("#2: invalid pairs", func(t *testing.T) {
+		_, err := parsePairs("A:")
+		assert.ErrorIs(t, err, errPairsInvalid)
+		_, err = parsePairs(" , ")
+		assert.ErrorIs(t, err, errPairsInvalid)
+	})
+}
+
+func Test_generate(t *testing.T) {
+	t.Run("#1: generated files of the example package are up to date", func(t *testing.T) {
+		pairs, err := parsePairs(examplePairs(t))
+		assert.Nil(t, err)
+		cfg := &config{dir: exampleDir, pairs: pairs, output: "deepcopy_gen.go", test: true,
+			tagName: deepcopy.DefaultTagName}
+		code, testCode, err := generate(cfg)
+		assert.Nil(t, err)
+
+		expectedCode, err := os.ReadFile(filepath.Join(exampleDir, cfg.output))
+		assert.Nil(t, err)
+		assert.Equal(t, string(expectedCode), string(code))
+		expectedTestCode, err := os.ReadFile(testFileName(filepath.Join(exampleDir, cfg.output)))
+		assert.Nil(t, err)
+		assert.Equal(t, string(expectedTestCode), string(testCode))
+	})
+
+	t.Run("#2: no test code generated when not required", func(t *testing.T) {
+		cfg := &config{dir: exampleDir, pairs: []typePair{{dst: "Node", src: "Node"}}, output: "deepcopy_gen.go",
+			tagName: deepcopy.DefaultTagName}
+		code, testCode, err := generate(cfg)
+		assert.Nil(t, err)
+		assert.Contains(t, string(code), "func deepCopyNode(dst *Node, src *Node) error {")
+		assert.Contains(t, string(code), "deepcopy.RegisterCopyFunc(deepCopyNode)")
+		assert.Nil(t, testCode)
+	})
+
+	t.Run("#3: type not found", func(t *testing.T) {
+		cfg := &config{dir: exampleDir, pairs: []typePair{{dst: "Node", src: "Unknown"}}, output: "deepcopy_gen.go",
+			tagName: deepcopy.DefaultTagName}
+		_, _, err := generate(cfg)
+		assert.ErrorIs(t, err, errTypeNotFound)
+	})
+
+	t.Run("#4: type is not struct", func(t *testing.T) {
+		cfg := &config{dir: exampleDir, pairs: []typePair{{dst: "Status", src: "Status"}}, output: "deepcopy_gen.go",
+			tagName: deepcopy.DefaultTagName}
+		_, _, err := generate(cfg)
+		assert.ErrorIs(t, err, errTypeUnsupported)
+	})
+
+	t.Run("#5: required field can't be copied", func(t *testing.T) {
+		cfg := &config{dir: exampleDir, pairs: []typePair{{dst: "AddressDTO", src: "Attr"}}, output: "deepcopy_gen.go",
+			tagName: deepcopy.DefaultTagName}
+		_, _, err := generate(cfg)
+		assert.ErrorIs(t, err, deepcopy.ErrFieldRequireCopying)
+	})
+
+	t.Run("#6: unmatched fields in strict matching", func(t *testing.T) {
+		cfg := &config{dir: exampleDir, pairs: []typePair{{dst: "EventView", src: "Attr"}}, output: "deepcopy_gen.go",
+			tagName: deepcopy.DefaultTagName}
+		_, _, err := generate(cfg)
+		assert.ErrorIs(t, err, deepcopy.ErrFieldUnmatched)
+	})
+}
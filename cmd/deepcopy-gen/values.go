@@ -0,0 +1,382 @@
+package main
+
+import (
+	"go/types"
+	"strings"
+)
+
+const (
+	valuerPkgPath = "database/sql/driver"
+)
+
+// isValuerToScanner checks if values of the source type are copied to the destination type via
+// `driver.Valuer` and `sql.Scanner` as the library does
+func (g *generator) isValuerToScanner(dst, src types.Type) bool {
+	return (isValuer(src) || isValuer(types.NewPointer(src))) && isScanner(types.NewPointer(dst)) &&
+		!types.ConvertibleTo(src, dst)
+}
+
+// isValuer checks if the type implements `driver.Valuer`
+func isValuer(typ types.Type) bool {
+	sig := lookupMethod(typ, "Value")
+	if sig == nil || sig.Params().Len() != 0 || sig.Results().Len() != 2 {
+		return false
+	}
+	named, ok := unalias(sig.Results().At(0).Type()).(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == valuerPkgPath &&
+		named.Obj().Name() == "Value" && types.Identical(sig.Results().At(1).Type(), errType)
+}
+
+// isScanner checks if the type implements `sql.Scanner`
+func isScanner(typ types.Type) bool {
+	sig := lookupMethod(typ, "Scan")
+	if sig == nil || sig.Params().Len() != 1 || sig.Results().Len() != 1 {
+		return false
+	}
+	iface, ok := unalias(sig.Params().At(0).Type()).(*types.Interface)
+	return ok && iface.Empty() && types.Identical(sig.Results().At(0).Type(), errType)
+}
+
+// lookupMethod returns signature of the method of the type, returns nil if not found
+func lookupMethod(typ types.Type, name string) *types.Signature {
+	methodSet := types.NewMethodSet(typ)
+	for i := 0; i < methodSet.Len(); i++ {
+		if method := methodSet.At(i).Obj(); method.Name() == name {
+			sig, _ := method.Type().(*types.Signature)
+			return sig
+		}
+	}
+	return nil
+}
+
+// pairOK checks if the generated function of the struct pair can be used to copy the structs,
+// that is it fails to copy only when the library fails too
+func (g *generator) pairOK(pair structPair) bool {
+	if ok, found := g.pairOKs[pair]; found {
+		return ok
+	}
+	// Assume the pair is OK while checking to handle recursive types
+	g.pairOKs[pair] = true
+	mark := len(g.pairOKKeys)
+	g.pairOKKeys = append(g.pairOKKeys, pair)
+
+	ok := g.checkPair(pair)
+	if !ok {
+		// Results depending on the wrong assumption are dropped
+		for _, p := range g.pairOKKeys[mark+1:] {
+			delete(g.pairOKs, p)
+		}
+		g.pairOKKeys = g.pairOKKeys[:mark+1]
+		g.pairOKs[pair] = false
+	}
+	return ok
+}
+
+// checkPair checks if the generated function of the struct pair can be used to copy the structs
+func (g *generator) checkPair(pair structPair) bool {
+	if g.isValuerToScanner(pair.dst, pair.src) {
+		return false
+	}
+	plan, err := g.plan(pair)
+	if err != nil {
+		return false
+	}
+	for _, fp := range plan.fields {
+		if fp.method != nil || g.shallowCopied(fp) {
+			continue
+		}
+		dstType, srcType := fp.dst.field.Type(), fp.src.field.Type()
+		if g.valueDirect(dstType, srcType, true) {
+			continue
+		}
+		// Copying via the library fails when building its copier, the generated function won't
+		// fail the same way when the field is not copied such as behind a nil pointer
+		if fp.required && g.canBuildFail(dstType, srcType) {
+			return false
+		}
+	}
+	return true
+}
+
+// unaddressableOK checks if the generated function of the struct pair can be used to copy
+// unaddressable source structs such as map values.
+// NOTE: the library can't access unexported fields of these values, so the generated
+// function is used only when there are none and all fields are copied directly.
+func (g *generator) unaddressableOK(pair structPair) bool {
+	if g.isValuerToScanner(pair.dst, pair.src) {
+		return false
+	}
+	plan, err := g.plan(pair)
+	if err != nil {
+		return false
+	}
+	for _, fp := range plan.fields {
+		for _, field := range fp.src.path {
+			if !field.Exported() {
+				return false
+			}
+		}
+		if fp.method != nil || g.shallowCopied(fp) {
+			continue
+		}
+		if !g.valueDirect(fp.dst.field.Type(), fp.src.field.Type(), false) {
+			return false
+		}
+	}
+	return true
+}
+
+// shallowCopied checks if the field is assigned as is
+func (g *generator) shallowCopied(fp *fieldPlan) bool {
+	return (fp.dst.shallow || fp.src.shallow) && types.AssignableTo(fp.src.field.Type(), fp.dst.field.Type())
+}
+
+// valueDirect checks if values of the source type are copied by the generated code,
+// the others are copied via the library
+//
+//nolint:gocyclo
+func (g *generator) valueDirect(dstType, srcType types.Type, addressable bool) bool {
+	dstType, srcType = unalias(dstType), unalias(srcType)
+	if isTime(srcType) && types.Identical(dstType, srcType) {
+		return true // Copied as is
+	}
+	if g.isForeign(dstType) || g.isForeign(srcType) {
+		return false
+	}
+	dstUnder, srcUnder := dstType.Underlying(), srcType.Underlying()
+	if isInterface(dstUnder) || isInterface(srcUnder) {
+		return false
+	}
+
+	switch src := srcUnder.(type) {
+	case *types.Basic:
+		if src.Kind() == types.UnsafePointer || src.Kind() == types.Uintptr {
+			return false
+		}
+		if types.Identical(dstType, srcType) || types.ConvertibleTo(srcType, dstType) {
+			return true
+		}
+	case *types.Signature:
+		if types.Identical(dstType, srcType) || types.ConvertibleTo(srcType, dstType) {
+			return true
+		}
+	case *types.Pointer:
+		if dst, ok := dstUnder.(*types.Pointer); ok {
+			return g.valueDirect(dst.Elem(), src.Elem(), true)
+		}
+		return g.valueDirect(dstType, src.Elem(), true)
+	}
+	if dst, ok := dstUnder.(*types.Pointer); ok {
+		return g.valueDirect(dst.Elem(), srcType, addressable)
+	}
+
+	switch src := srcUnder.(type) {
+	case *types.Slice:
+		if dstElem := sliceElem(dstUnder); dstElem != nil {
+			return g.valueDirect(dstElem, src.Elem(), true)
+		}
+	case *types.Array:
+		if dstElem := sliceElem(dstUnder); dstElem != nil {
+			return g.valueDirect(dstElem, src.Elem(), addressable)
+		}
+	case *types.Struct:
+		dstNamed, dstOK := dstType.(*types.Named)
+		srcNamed, srcOK := srcType.(*types.Named)
+		if !dstOK || !srcOK || !g.isLocalStruct(dstNamed) || !g.isLocalStruct(srcNamed) {
+			return false
+		}
+		pair := structPair{dst: dstNamed, src: srcNamed}
+		if addressable {
+			return g.pairOK(pair)
+		}
+		return g.unaddressableOK(pair)
+	case *types.Map:
+		if dst, ok := dstUnder.(*types.Map); ok {
+			return g.valueDirect(dst.Key(), src.Key(), false) && g.valueDirect(dst.Elem(), src.Elem(), false)
+		}
+	}
+	return false
+}
+
+// canBuildFail checks if the library may fail to build its copier for the types
+func (g *generator) canBuildFail(dstType, srcType types.Type) bool {
+	dstType, srcType = unalias(dstType), unalias(srcType)
+	if isInterface(dstType.Underlying()) || isInterface(srcType.Underlying()) {
+		return false // Copiers of interfaces are built when copying
+	}
+	if !types.Identical(dstType, srcType) {
+		return true
+	}
+	return g.hasNonCopyable(srcType, map[types.Type]bool{})
+}
+
+// hasNonCopyable checks if the type may contain values which the library can't copy
+// such as channels and unsafe pointers
+func (g *generator) hasNonCopyable(typ types.Type, visited map[types.Type]bool) bool {
+	typ = unalias(typ)
+	if named, ok := typ.(*types.Named); ok {
+		if visited[named] {
+			return false
+		}
+		visited[named] = true
+		// Copying methods may not accept the fields
+		if _, isStruct := named.Underlying().(*types.Struct); isStruct {
+			if copyingMethods, _ := parseMethods(named); len(copyingMethods) > 0 {
+				return true
+			}
+		}
+	}
+
+	switch t := typ.Underlying().(type) {
+	case *types.Basic:
+		return t.Kind() == types.UnsafePointer
+	case *types.Chan:
+		return true
+	case *types.Pointer:
+		return g.hasNonCopyable(t.Elem(), visited)
+	case *types.Slice:
+		return g.hasNonCopyable(t.Elem(), visited)
+	case *types.Array:
+		return g.hasNonCopyable(t.Elem(), visited)
+	case *types.Map:
+		return g.hasNonCopyable(t.Key(), visited) || g.hasNonCopyable(t.Elem(), visited)
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			detail := &fieldDetail{field: t.Field(i)}
+			g.parseTag(detail, t.Tag(i))
+			// Errors of unexported fields not required are ignored
+			if detail.ignored || (!detail.field.Exported() && !detail.required) {
+				continue
+			}
+			if g.hasNonCopyable(detail.field.Type(), visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// mayFail checks if the generated function of the struct pair may return errors
+func (g *generator) mayFail(pair structPair) bool {
+	if result, found := g.mayFails[pair]; found {
+		return result
+	}
+	// Assume the function may fail while checking to handle recursive types
+	g.mayFails[pair] = true
+
+	plan := g.plans[pair]
+	result := plan.postCopy
+	for _, fp := range plan.fields {
+		if fp.method != nil || (fp.required && g.fieldMayFail(fp)) {
+			result = true
+		}
+	}
+	g.mayFails[pair] = result
+	return result
+}
+
+// fieldMayFail checks if the generated copying of the field may fail
+func (g *generator) fieldMayFail(fp *fieldPlan) bool {
+	if g.shallowCopied(fp) {
+		return false
+	}
+	dstType, srcType := fp.dst.field.Type(), fp.src.field.Type()
+	if !g.valueDirect(dstType, srcType, true) {
+		return true
+	}
+	return g.valueMayFail(dstType, srcType)
+}
+
+// valueMayFail checks if the generated copying of the values may fail.
+// NOTE: the values must be copied by the generated code.
+func (g *generator) valueMayFail(dstType, srcType types.Type) bool {
+	dstType, srcType = unalias(dstType), unalias(srcType)
+	dstUnder, srcUnder := dstType.Underlying(), srcType.Underlying()
+
+	if isTime(srcType) {
+		return false
+	}
+	switch src := srcUnder.(type) {
+	case *types.Basic, *types.Signature:
+		return false
+	case *types.Pointer:
+		if dst, ok := dstUnder.(*types.Pointer); ok {
+			return g.valueMayFail(dst.Elem(), src.Elem())
+		}
+		return g.valueMayFail(dstType, src.Elem())
+	}
+	if dst, ok := dstUnder.(*types.Pointer); ok {
+		return g.valueMayFail(dst.Elem(), srcType)
+	}
+
+	switch src := srcUnder.(type) {
+	case *types.Slice:
+		return g.valueMayFail(sliceElem(dstUnder), src.Elem())
+	case *types.Array:
+		return g.valueMayFail(sliceElem(dstUnder), src.Elem())
+	case *types.Struct:
+		dstNamed, _ := dstType.(*types.Named)
+		srcNamed, _ := srcType.(*types.Named)
+		return g.mayFail(structPair{dst: dstNamed, src: srcNamed})
+	case *types.Map:
+		dst, _ := dstUnder.(*types.Map)
+		return g.valueMayFail(dst.Key(), src.Key()) || g.valueMayFail(dst.Elem(), src.Elem())
+	}
+	return true
+}
+
+// isInterface checks if the type is an interface type
+func isInterface(typ types.Type) bool {
+	_, ok := typ.(*types.Interface)
+	return ok
+}
+
+// isTime checks if the type is time.Time
+func isTime(typ types.Type) bool {
+	named, ok := unalias(typ).(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "time" && named.Obj().Name() == "Time"
+}
+
+// isSimple checks if values of the type are copied by assigning
+func isSimple(typ types.Type) bool {
+	if isTime(typ) {
+		return true
+	}
+	switch typ.Underlying().(type) {
+	case *types.Basic, *types.Signature:
+		return true
+	}
+	return false
+}
+
+// sliceElem returns element type of the slice or array type, returns nil for other types
+func sliceElem(typ types.Type) types.Type {
+	switch t := typ.(type) {
+	case *types.Slice:
+		return t.Elem()
+	case *types.Array:
+		return t.Elem()
+	}
+	return nil
+}
+
+// operand returns the expression which can be used as operand of index and selector expressions
+func operand(expr string) string {
+	if strings.HasPrefix(expr, "*") {
+		return "(" + expr + ")"
+	}
+	return expr
+}
+
+// deref returns the expression of dereferencing the pointer expression
+func deref(expr string) string {
+	return "*" + expr
+}
+
+// addr returns the expression of the address of the addressable expression
+func addr(expr string) string {
+	if strings.HasPrefix(expr, "*") {
+		return expr[1:]
+	}
+	return "&" + expr
+}
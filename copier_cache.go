@@ -0,0 +1,305 @@
+package deepcopy
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+var (
+	// globalCopierCache global cache for any parsed type
+	globalCopierCache = &copierCache{}
+)
+
+// CacheStatistics statistics of the global copier cache
+type CacheStatistics struct {
+	// Entries number of cached copiers
+	Entries int
+	// Hits number of lookups finding cached copiers
+	Hits uint64
+	// Misses number of lookups finding no cached copiers
+	Misses uint64
+	// Builds number of copiers built and added to the cache
+	Builds uint64
+	// Evictions number of copiers evicted as the cache is full
+	Evictions uint64
+}
+
+// CachedTypePair destination and source types of a cached copier
+type CachedTypePair struct {
+	DstType reflect.Type
+	SrcType reflect.Type
+}
+
+// CacheStats returns statistics of the global copier cache
+func CacheStats() CacheStatistics {
+	return globalCopierCache.stats()
+}
+
+// CachedTypePairs returns type pairs of the copiers in the global cache for debugging.
+// There is an item for each cached copier, so a pair can appear more than once when it's copied
+// with different options.
+func CachedTypePairs() []CachedTypePair {
+	return globalCopierCache.typePairs()
+}
+
+// SetCacheMaxSize sets the max number of copiers in the global cache, 0 means unlimited (default is `0`).
+// When the cache is full, the least recently used copiers are evicted. Recency is approximate: it's tracked
+// by a clock advancing when copiers are added, so copiers used between two additions are equally recent.
+func SetCacheMaxSize(size int) {
+	globalCopierCache.setMaxSize(size)
+}
+
+// copierCache cache of built copiers, it's safe for concurrent use.
+// Reads are lock-free as copiers are built once and read many times, writes are serialized.
+// Like `sync.Map`, new entries are added to a dirty map which replaces the read-only map once
+// lookups missing the read-only map cost as much as copying it, but keys are not boxed in interfaces.
+// A `nil` copier is cached for struct types whose copier building is in-progress,
+// such placeholders are never evicted.
+type copierCache struct {
+	// NOTE: 64-bit fields accessed atomically are put first to be aligned on 32-bit platforms
+	// clock increases on every write, entries read keep the clock value as their last use time.
+	// NOTE: recency is only as precise as writes are frequent, which is enough for evicting unused copiers
+	// while keeping lookups free of shared writes except the first one after every write.
+	clock     uint64
+	misses    uint64
+	builds    uint64
+	evictions uint64
+	// hits counters of lookups finding cached copiers, they are striped by goroutines
+	// so that concurrent lookups don't write the same memory
+	hits [hitStripes]hitCounter
+
+	// read map of entries read without locking, a stored map is never modified
+	read atomic.Value // map[cacheKey]*copierCacheEntry
+	mu   sync.Mutex
+	// dirty map of all entries including the ones not in the read map yet, nil when it's the same as the read map
+	dirty map[cacheKey]*copierCacheEntry
+	// dirtyMisses number of lookups missing the read map since the dirty map was made
+	dirtyMisses int
+	maxSize     int
+}
+
+const (
+	// hitStripeBits, hitStripes number of counters of cache hits
+	hitStripeBits = 4
+	hitStripes    = 1 << hitStripeBits
+)
+
+// hitCounter counter of cache hits padded to fill a cache line
+type hitCounter struct {
+	n uint64
+	_ [56]byte
+}
+
+// hitStripe returns the index of the hit counter for the calling goroutine.
+// It's derived from the address of a stack variable as goroutines run on distinct stacks.
+func hitStripe() int {
+	var b byte
+	addr := uint64(uintptr(unsafe.Pointer(&b)))                           //nolint:gosec
+	return int((addr >> 10) * 0x9E3779B97F4A7C15 >> (64 - hitStripeBits)) //nolint:mnd
+}
+
+// copierCacheEntry entry of copier cache
+type copierCacheEntry struct {
+	lastUsed uint64
+	copier   copier
+}
+
+// get returns the cached copier of the key and whether the key is found
+func (c *copierCache) get(key cacheKey) (copier, bool) {
+	read, _ := c.read.Load().(map[cacheKey]*copierCacheEntry)
+	entry, found := read[key]
+	if !found {
+		c.mu.Lock()
+		if entry, found = c.dirty[key]; c.dirty != nil {
+			c.dirtyMisses++
+			if c.dirtyMisses >= len(c.dirty) {
+				c.promote()
+			}
+		}
+		c.mu.Unlock()
+	}
+	if !found {
+		atomic.AddUint64(&c.misses, 1)
+		return nil, false
+	}
+	if entry.copier == nil {
+		atomic.AddUint64(&c.misses, 1)
+		return nil, true
+	}
+	atomic.AddUint64(&c.hits[hitStripe()].n, 1)
+	// NOTE: only update the use time when it changes to avoid unnecessary writes of shared memory
+	if clock := atomic.LoadUint64(&c.clock); atomic.LoadUint64(&entry.lastUsed) != clock {
+		atomic.StoreUint64(&entry.lastUsed, clock)
+	}
+	return entry.copier, true
+}
+
+// set sets the copier of the key, the copier can be `nil`
+func (c *copierCache) set(key cacheKey, cp copier) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, inRead := c.loadRead()[key]
+	c.makeDirty()[key] = &copierCacheEntry{copier: cp, lastUsed: atomic.AddUint64(&c.clock, 1)}
+	// Entries of the read map are replaced at once, so placeholders don't stay there
+	if inRead {
+		c.promote()
+	}
+	if cp != nil {
+		atomic.AddUint64(&c.builds, 1)
+	}
+	if c.maxSize > 0 && len(c.entries()) > c.maxSize {
+		c.evict()
+	}
+}
+
+// delete deletes the copier of the key
+func (c *copierCache) delete(key cacheKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, found := c.entries()[key]; !found {
+		return
+	}
+	_, inRead := c.loadRead()[key]
+	delete(c.makeDirty(), key)
+	if inRead {
+		c.promote()
+	}
+}
+
+// clear deletes all cached copiers except placeholders of in-progress building
+func (c *copierCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entries := c.makeDirty()
+	for key, entry := range entries {
+		if entry.copier != nil {
+			delete(entries, key)
+		}
+	}
+	c.promote()
+}
+
+// len returns the number of cached copiers
+func (c *copierCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries())
+}
+
+// setMaxSize sets the max number of cached copiers and evicts copiers if the cache is full
+func (c *copierCache) setMaxSize(maxSize int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maxSize = maxSize
+	if c.maxSize > 0 && len(c.entries()) > c.maxSize {
+		c.evict()
+	}
+}
+
+// loadRead returns the read map.
+// NOTE: the returned map must not be modified.
+func (c *copierCache) loadRead() map[cacheKey]*copierCacheEntry {
+	read, _ := c.read.Load().(map[cacheKey]*copierCacheEntry)
+	return read
+}
+
+// entries returns the map of all entries.
+// NOTE: the caller must hold the write lock and must not modify the returned map.
+func (c *copierCache) entries() map[cacheKey]*copierCacheEntry {
+	if c.dirty != nil {
+		return c.dirty
+	}
+	return c.loadRead()
+}
+
+// makeDirty returns the dirty map, it's made from the read map if there is none.
+// NOTE: the caller must hold the write lock.
+func (c *copierCache) makeDirty() map[cacheKey]*copierCacheEntry {
+	if c.dirty == nil {
+		read := c.loadRead()
+		c.dirty = make(map[cacheKey]*copierCacheEntry, len(read)+1)
+		for key, entry := range read {
+			c.dirty[key] = entry
+		}
+		c.dirtyMisses = 0
+	}
+	return c.dirty
+}
+
+// promote replaces the read map with the dirty map.
+// NOTE: the caller must hold the write lock.
+func (c *copierCache) promote() {
+	if c.dirty == nil {
+		return
+	}
+	c.read.Store(c.dirty)
+	c.dirty = nil
+	c.dirtyMisses = 0
+}
+
+// evict evicts the least recently used copiers to leave room for new ones.
+// About 1/8 of the max size is evicted at once, so eviction doesn't happen on every write.
+// NOTE: the caller must hold the write lock.
+func (c *copierCache) evict() {
+	type keyEntry struct {
+		key      cacheKey
+		lastUsed uint64
+	}
+	allEntries := c.makeDirty()
+	entries := make([]keyEntry, 0, len(allEntries))
+	for key, entry := range allEntries {
+		if entry.copier != nil {
+			entries = append(entries, keyEntry{key: key, lastUsed: atomic.LoadUint64(&entry.lastUsed)})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].lastUsed < entries[j].lastUsed
+	})
+
+	numEvictions := len(allEntries) - c.maxSize + c.maxSize/8 //nolint:mnd
+	if numEvictions > len(entries) {
+		numEvictions = len(entries)
+	}
+	for _, entry := range entries[:numEvictions] {
+		delete(allEntries, entry.key)
+	}
+	c.promote()
+	atomic.AddUint64(&c.evictions, uint64(numEvictions))
+}
+
+// stats returns statistics of the cache
+func (c *copierCache) stats() CacheStatistics {
+	var hits uint64
+	for i := range c.hits {
+		hits += atomic.LoadUint64(&c.hits[i].n)
+	}
+	return CacheStatistics{
+		Entries:   c.len(),
+		Hits:      hits,
+		Misses:    atomic.LoadUint64(&c.misses),
+		Builds:    atomic.LoadUint64(&c.builds),
+		Evictions: atomic.LoadUint64(&c.evictions),
+	}
+}
+
+// typePairs returns type pairs of the cached copiers sorted by their names
+func (c *copierCache) typePairs() []CachedTypePair {
+	var pairs []CachedTypePair
+	c.mu.Lock()
+	for key, entry := range c.entries() {
+		if entry.copier != nil {
+			pairs = append(pairs, CachedTypePair{DstType: key.dstType, SrcType: key.srcType})
+		}
+	}
+	c.mu.Unlock()
+	sort.Slice(pairs, func(i, j int) bool {
+		if dst1, dst2 := pairs[i].DstType.String(), pairs[j].DstType.String(); dst1 != dst2 {
+			return dst1 < dst2
+		}
+		return pairs[i].SrcType.String() < pairs[j].SrcType.String()
+	})
+	return pairs
+}
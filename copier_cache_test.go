@@ -0,0 +1,153 @@
+package deepcopy
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testCacheKeyOf(n int) cacheKey {
+	typ := reflect.ArrayOf(n, typeOf[int]())
+	return cacheKey{dstType: typ, srcType: typ}
+}
+
+func Test_copierCache(t *testing.T) {
+	t.Run("#1: get, set and delete", func(t *testing.T) {
+		c := &copierCache{}
+		cp, found := c.get(testCacheKeyOf(1))
+		assert.Nil(t, cp)
+		assert.False(t, found)
+
+		c.set(testCacheKeyOf(1), defaultDirectCopier)
+		c.set(testCacheKeyOf(1), defaultConvCopier)
+		cp, found = c.get(testCacheKeyOf(1))
+		assert.Equal(t, defaultConvCopier, cp)
+		assert.True(t, found)
+		assert.Equal(t, 1, c.len())
+
+		c.delete(testCacheKeyOf(1))
+		c.delete(testCacheKeyOf(1))
+		_, found = c.get(testCacheKeyOf(1))
+		assert.False(t, found)
+		assert.Equal(t, CacheStatistics{Entries: 0, Hits: 1, Misses: 2, Builds: 2}, c.stats())
+	})
+
+	t.Run("#2: placeholders of in-progress building", func(t *testing.T) {
+		c := &copierCache{}
+		c.set(testCacheKeyOf(1), nil)
+		c.set(testCacheKeyOf(2), defaultDirectCopier)
+		cp, found := c.get(testCacheKeyOf(1))
+		assert.Nil(t, cp)
+		assert.True(t, found)
+
+		c.clear()
+		_, found = c.get(testCacheKeyOf(1))
+		assert.True(t, found)
+		_, found = c.get(testCacheKeyOf(2))
+		assert.False(t, found)
+		assert.Equal(t, CacheStatistics{Entries: 1, Misses: 3, Builds: 1}, c.stats())
+	})
+
+	t.Run("#3: evict least recently used copiers", func(t *testing.T) {
+		c := &copierCache{}
+		c.setMaxSize(8)
+		for i := 1; i <= 8; i++ {
+			c.set(testCacheKeyOf(i), defaultDirectCopier)
+		}
+		// Entries 1 and 2 become the most recently used ones
+		c.get(testCacheKeyOf(1))
+		c.get(testCacheKeyOf(2))
+		c.set(testCacheKeyOf(9), defaultDirectCopier)
+
+		// 1 entry over the max size and 1/8 of the max size are evicted
+		assert.Equal(t, 7, c.len())
+		assert.Equal(t, uint64(2), c.stats().Evictions)
+		for i, expected := range []bool{false, true, true, false, false, true, true, true, true, true} {
+			_, found := c.get(testCacheKeyOf(i))
+			assert.Equal(t, expected, found, "entry %d", i)
+		}
+
+		// Placeholders are never evicted
+		c.set(testCacheKeyOf(0), nil)
+		c.setMaxSize(2)
+		assert.Equal(t, 2, c.len())
+		_, found := c.get(testCacheKeyOf(0))
+		assert.True(t, found)
+	})
+
+	t.Run("#4: cached type pairs", func(t *testing.T) {
+		c := &copierCache{}
+		c.set(cacheKey{dstType: typeOf[string](), srcType: typeOf[int]()}, defaultConvCopier)
+		c.set(cacheKey{dstType: typeOf[int](), srcType: typeOf[int8]()}, defaultConvCopier)
+		c.set(cacheKey{dstType: typeOf[int](), srcType: typeOf[int16]()}, nil)
+		assert.Equal(t, []CachedTypePair{
+			{DstType: typeOf[int](), SrcType: typeOf[int8]()},
+			{DstType: typeOf[string](), SrcType: typeOf[int]()},
+		}, c.typePairs())
+	})
+
+	t.Run("#5: new entries are read without locking after enough lookups", func(t *testing.T) {
+		c := &copierCache{}
+		c.set(testCacheKeyOf(1), defaultDirectCopier)
+		c.set(testCacheKeyOf(2), defaultDirectCopier)
+		assert.Equal(t, 0, len(c.loadRead()))
+
+		c.get(testCacheKeyOf(1))
+		c.get(testCacheKeyOf(2))
+		assert.Equal(t, 2, len(c.loadRead()))
+		assert.Nil(t, c.dirty)
+
+		// Replaced and deleted entries don't stay in the read map
+		c.set(testCacheKeyOf(1), defaultConvCopier)
+		cp, _ := c.get(testCacheKeyOf(1))
+		assert.Equal(t, defaultConvCopier, cp)
+		c.delete(testCacheKeyOf(2))
+		_, found := c.get(testCacheKeyOf(2))
+		assert.False(t, found)
+	})
+
+	t.Run("#6: hits counted by concurrent lookups", func(t *testing.T) {
+		c := &copierCache{}
+		c.set(testCacheKeyOf(1), defaultDirectCopier)
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					c.get(testCacheKeyOf(1))
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, uint64(5000), c.stats().Hits)
+	})
+}
+
+func Test_CacheStats(t *testing.T) {
+	type SS struct {
+		I int
+	}
+	type DD struct {
+		I int
+	}
+	ClearCache()
+	defer SetCacheMaxSize(0)
+	SetCacheMaxSize(100)
+
+	stats := CacheStats()
+	var d DD
+	err := Copy(&d, SS{I: 1})
+	assert.Nil(t, err)
+	err = Copy(&d, SS{I: 2})
+	assert.Nil(t, err)
+
+	newStats := CacheStats()
+	assert.Equal(t, 1, newStats.Entries)
+	assert.Equal(t, stats.Builds+1, newStats.Builds)
+	assert.Equal(t, stats.Hits+1, newStats.Hits)
+	assert.Equal(t, stats.Misses+1, newStats.Misses)
+	assert.Equal(t, []CachedTypePair{{DstType: typeOf[DD](), SrcType: typeOf[SS]()}}, CachedTypePairs())
+}
@@ -0,0 +1,124 @@
+package deepcopy
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// copyFuncKey key of registered copy functions
+type copyFuncKey struct {
+	dstType reflect.Type
+	srcType reflect.Type
+}
+
+var (
+	// copyFuncMap registered copy functions
+	copyFuncMap = map[copyFuncKey]copyFunc{}
+
+	// copyFuncMu read/write lock of registered copy functions
+	copyFuncMu sync.RWMutex
+)
+
+// RegisterCopyFunc registers a function copying values of struct type S to struct type D such as ones
+// generated by `cmd/deepcopy-gen`. Copy uses the function for the types when the copying follows the
+// default settings, that is the settings the function is generated for.
+// This function should be called at program startup, e.g. in `init()`.
+func RegisterCopyFunc[D, S any](fn func(dst *D, src *S) error) {
+	key := copyFuncKey{dstType: typeOf[D](), srcType: typeOf[S]()}
+	copyFuncMu.Lock()
+	copyFuncMap[key] = &copyFuncCopier[D, S]{fn: fn}
+	copyFuncMu.Unlock()
+
+	// Copiers built before may not use the function
+	ClearCache()
+}
+
+// copyFuncsApplicable checks if the registered copy functions can be used by the context
+// as they are made for the default settings only
+func (ctx *Context) copyFuncsApplicable() bool {
+	return ctx.UseRegisteredCopyFuncs && ctx.CopyBetweenPtrAndValue && ctx.CopyViaCopyingMethod &&
+		!ctx.IgnoreNonCopyableTypes && !ctx.CopyViaDeepCopyMethod && !ctx.CopyIntoIfaceValue && ctx.depthLeft == 0 &&
+		!ctx.hasFieldMasks() && ctx.shallowTypes == nil && ctx.opaqueTypes == nil &&
+		ctx.ChanCopyPolicy == ChanPolicyNonCopyable && ctx.UnsafeCopyPolicy == UnsafePolicyDefault &&
+		ctx.TextConversion == TextConvNone && !ctx.TimeConversion && ctx.StrictMatching == StrictModeNone
+}
+
+// buildCopierForCopyFuncs returns copier of the copy function registered for the types,
+// returns nil if there is none
+func buildCopierForCopyFuncs(ctx *Context, dstType, srcType reflect.Type) copier {
+	if !ctx.copyFuncsApplicable() {
+		return nil
+	}
+	copyFuncMu.RLock()
+	fn := copyFuncMap[copyFuncKey{dstType: dstType, srcType: srcType}]
+	copyFuncMu.RUnlock()
+	if fn == nil {
+		return nil
+	}
+	return fn.withContext(ctx)
+}
+
+// withoutCopyFuncs returns a context not using registered copy functions
+func (ctx *Context) withoutCopyFuncs() *Context {
+	options := *ctx.copierOptions
+	options.flags &^= 1 << flagUseRegisteredCopyFuncs
+	newCtx := ctx.withOptions(options)
+	newCtx.UseRegisteredCopyFuncs = false
+	return newCtx
+}
+
+// copyFunc registered copy function which can make copiers for contexts
+type copyFunc interface {
+	withContext(ctx *Context) copier
+}
+
+// copyFuncCopier data structure of copier that copies via a registered copy function
+type copyFuncCopier[D, S any] struct {
+	ctx *Context
+	fn  func(dst *D, src *S) error
+}
+
+// withContext returns a copier of the function for the context
+func (c *copyFuncCopier[D, S]) withContext(ctx *Context) copier {
+	return &copyFuncCopier[D, S]{ctx: ctx, fn: c.fn}
+}
+
+// Copy implementation of Copy function for copy function copier
+func (c *copyFuncCopier[D, S]) Copy(dst, src reflect.Value) error {
+	// NOTE: unexported fields are not copied from unaddressable values, copy them reflectively
+	// to keep the same results
+	if !src.CanAddr() {
+		cp, err := buildCopier(c.ctx.withoutCopyFuncs(), dst.Type(), src.Type())
+		if err != nil {
+			return err
+		}
+		return cp.Copy(dst, src)
+	}
+	return c.fn(dst.Addr().Interface().(*D), src.Addr().Interface().(*S)) //nolint:forcetypeassert
+}
+
+// CopyValue performs deep copy from the value pointed by `src` to the value pointed by `dst` with the default
+// settings. Unlike Copy, the declared types `D` and `S` are used instead of the dynamic types of the arguments,
+// so interfaces and pointers are copied the same way as they are struct fields.
+// It is used by generated copy functions for values they don't copy by themselves.
+func CopyValue[D, S any](dst *D, src *S) error {
+	if dst == nil || src == nil {
+		return fmt.Errorf("%w: source and destination must be non-nil", ErrValueInvalid)
+	}
+	ctx := defaultContext()
+	if err := ctx.prepare(); err != nil {
+		return err
+	}
+	cp, err := buildCopier(ctx, typeOf[D](), typeOf[S]())
+	if err != nil {
+		return err
+	}
+	return cp.Copy(reflect.ValueOf(dst).Elem(), reflect.ValueOf(src).Elem())
+}
+
+// SetNilOnZero sets the value as `nil` when its inner value is zero, the same as tag option `nilonzero` does.
+// It applies to values of pointers, interfaces, slices and maps, and is used by generated copy functions.
+func SetNilOnZero[T any](v *T) {
+	nillableValueSetNilOnZero(reflect.ValueOf(v).Elem())
+}
@@ -0,0 +1,143 @@
+package deepcopy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testFuncSrc struct {
+	Name  string
+	Items []int
+	note  string
+}
+
+type testFuncDst struct {
+	Name  string
+	Items []int
+	note  string
+}
+
+var errTestFunc = errors.New("test copy func error")
+
+func Test_RegisterCopyFunc(t *testing.T) {
+	calls := 0
+	RegisterCopyFunc(func(dst *testFuncDst, src *testFuncSrc) error {
+		calls++
+		if src.Name == "error" {
+			return errTestFunc
+		}
+		dst.Name = src.Name + "(func)"
+		dst.Items = append([]int(nil), src.Items...)
+		dst.note = src.note
+		return nil
+	})
+	defer func() {
+		copyFuncMu.Lock()
+		delete(copyFuncMap, copyFuncKey{dstType: typeOf[testFuncDst](), srcType: typeOf[testFuncSrc]()})
+		copyFuncMu.Unlock()
+		ClearCache()
+	}()
+
+	t.Run("#1: copy via registered function", func(t *testing.T) {
+		calls = 0
+		src := testFuncSrc{Name: "a", Items: []int{1, 2}, note: "n"}
+		var dst testFuncDst
+		err := Copy(&dst, &src)
+		assert.Nil(t, err)
+		assert.Equal(t, testFuncDst{Name: "a(func)", Items: []int{1, 2}, note: "n"}, dst)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("#2: copy via registered function for nested values", func(t *testing.T) {
+		calls = 0
+		src := map[string]*testFuncSrc{"x": {Name: "x"}, "y": {Name: "y"}}
+		var dst map[string]testFuncDst
+		err := Copy(&dst, src)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]testFuncDst{"x": {Name: "x(func)"}, "y": {Name: "y(func)"}}, dst)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("#3: registered function returns error", func(t *testing.T) {
+		var dst testFuncDst
+		err := Copy(&dst, &testFuncSrc{Name: "error"})
+		assert.ErrorIs(t, err, errTestFunc)
+	})
+
+	t.Run("#4: copy unaddressable values reflectively", func(t *testing.T) {
+		calls = 0
+		var dst testFuncDst
+		err := Copy(&dst, testFuncSrc{Name: "a", note: "n"})
+		assert.Nil(t, err)
+		assert.Equal(t, testFuncDst{Name: "a"}, dst)
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("#5: registered functions not used when disabled", func(t *testing.T) {
+		calls = 0
+		var dst testFuncDst
+		err := Copy(&dst, &testFuncSrc{Name: "a", note: "n"}, UseRegisteredCopyFuncs(false))
+		assert.Nil(t, err)
+		assert.Equal(t, testFuncDst{Name: "a", note: "n"}, dst)
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("#6: registered functions not used with non-default settings", func(t *testing.T) {
+		calls = 0
+		var dst testFuncDst
+		err := Copy(&dst, &testFuncSrc{Name: "a"}, MaxDepth(5))
+		assert.Nil(t, err)
+		assert.Equal(t, testFuncDst{Name: "a"}, dst)
+		assert.Equal(t, 0, calls)
+	})
+}
+
+func Test_CopyValue(t *testing.T) {
+	t.Run("#1: copy between declared types", func(t *testing.T) {
+		src := 10
+		var srcAny any = &src
+		var dst *int
+		err := CopyValue(&dst, &srcAny)
+		assert.Nil(t, err)
+		assert.Equal(t, 10, *dst)
+		assert.NotSame(t, &src, dst)
+	})
+
+	t.Run("#2: copy nil pointer to pointer", func(t *testing.T) {
+		var src *int
+		dst := new(int)
+		err := CopyValue(&dst, &src)
+		assert.Nil(t, err)
+		assert.Nil(t, dst)
+	})
+
+	t.Run("#3: nil arguments", func(t *testing.T) {
+		err := CopyValue[int, int](nil, nil)
+		assert.ErrorIs(t, err, ErrValueInvalid)
+	})
+
+	t.Run("#4: non-copyable types", func(t *testing.T) {
+		var dst chan int
+		src := make(chan int)
+		err := CopyValue(&dst, &src)
+		assert.ErrorIs(t, err, ErrTypeNonCopyable)
+	})
+}
+
+func Test_SetNilOnZero(t *testing.T) {
+	zero := 0
+	p := &zero
+	SetNilOnZero(&p)
+	assert.Nil(t, p)
+
+	s := []int{}
+	SetNilOnZero(&s)
+	assert.Nil(t, s)
+
+	one := 1
+	p = &one
+	SetNilOnZero(&p)
+	assert.NotNil(t, p)
+}
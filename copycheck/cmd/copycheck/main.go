@@ -0,0 +1,19 @@
+// Command copycheck reports mistakes in struct tags and copying methods used by `deepcopy.Copy`.
+//
+// Usage with `go vet`:
+//
+//	go install github.com/tiendc/go-deepcopy/copycheck/cmd/copycheck@latest
+//	go vet -vettool=$(which copycheck) ./...
+//
+// Use flag `-copycheck.tag` to check struct tags of another name.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/tiendc/go-deepcopy/copycheck"
+)
+
+func main() {
+	singlechecker.Main(copycheck.Analyzer)
+}
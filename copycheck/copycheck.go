@@ -0,0 +1,317 @@
+// Package copycheck defines an analyzer reporting mistakes in struct tags and copying methods
+// which `deepcopy.Copy` silently ignores at runtime:
+//
+//   - unknown, duplicate or malformed options of the copy tag such as `copy:",requried"`,
+//   - tag option `nilonzero` used on fields of non-nillable types,
+//   - tag option `strict` used on non-blank fields,
+//   - `Copy<Key>` and `PostCopy` methods having signatures the library doesn't accept,
+//   - `Copy<Key>` and `PostCopy` methods having value receivers, changes made by them are lost.
+//
+// Methods `Copy<Key>` are only checked when `<Key>` is a field key of the struct or of a struct
+// copied to it via `deepcopy.Copy` in the same package, other methods are not looked up by the library.
+//
+// The analyzer can be run via `go vet -vettool` with command `copycheck/cmd/copycheck`
+// or as a module plugin of golangci-lint.
+package copycheck
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const (
+	// DefaultTagName default name of struct tag parsed by the library
+	DefaultTagName = "copy"
+
+	methodPrefixCopy = "Copy"
+	methodPostCopy   = "PostCopy"
+
+	// maxSuggestionDistance max edit distance of a known option to suggest for an unknown one
+	maxSuggestionDistance = 2
+
+	// deepcopyPkgPath import path of the library
+	deepcopyPkgPath = "github.com/tiendc/go-deepcopy"
+	// maxPairDepth max depth of nested types paired when collecting keys of copied structs
+	maxPairDepth = 32
+)
+
+var (
+	// flagOptions tag options taking no value
+	flagOptions = map[string]bool{"required": true, "nilonzero": true, "shallow": true, "utc": true}
+
+	// knownOptions all tag options parsed by the library
+	knownOptions = []string{"required", "nilonzero", "shallow", "layout", "unix", "utc", "strict"}
+
+	// unixUnits values accepted by tag option `unix`
+	unixUnits = map[string]bool{"s": true, "ms": true, "us": true, "ns": true}
+
+	// strictModes values accepted by tag option `strict`
+	strictModes = map[string]bool{"": true, "all": true, "src": true, "dst": true, "none": true}
+
+	errType = types.Universe.Lookup("error").Type()
+
+	// copyFuncs functions of the library copying their second argument to the first one
+	copyFuncs = map[string]bool{"Copy": true, "CopyValue": true}
+)
+
+// Analyzer reports mistakes in copy tags and copying methods
+var Analyzer = NewAnalyzer(DefaultTagName)
+
+// checker checks struct fields and methods of a package
+type checker struct {
+	tagName string
+}
+
+// NewAnalyzer creates an analyzer checking struct tags of the given name
+func NewAnalyzer(tagName string) *analysis.Analyzer {
+	c := &checker{tagName: tagName}
+	a := &analysis.Analyzer{
+		Name:     "copycheck",
+		Doc:      "report mistakes in struct tags and copying methods used by deepcopy",
+		URL:      "https://github.com/tiendc/go-deepcopy",
+		Requires: []*analysis.Analyzer{inspect.Analyzer},
+		Run:      c.run,
+	}
+	a.Flags.StringVar(&c.tagName, "tag", tagName, "name of struct tag to check")
+	return a
+}
+
+func (c *checker) run(pass *analysis.Pass) (any, error) {
+	insp, _ := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	keys := &copyingKeys{tagName: c.tagName, keys: map[*types.TypeName]map[string]bool{}}
+	var methods []*ast.FuncDecl
+	nodeFilter := []ast.Node{(*ast.StructType)(nil), (*ast.FuncDecl)(nil), (*ast.CallExpr)(nil)}
+	insp.Preorder(nodeFilter, func(node ast.Node) {
+		switch node := node.(type) {
+		case *ast.StructType:
+			for _, field := range node.Fields.List {
+				c.checkField(pass, field)
+			}
+		case *ast.FuncDecl:
+			methods = append(methods, node)
+		case *ast.CallExpr:
+			keys.addCall(pass, node)
+		}
+	})
+	// Copying methods are checked once keys of all source types copied in the package are known
+	for _, decl := range methods {
+		checkMethod(pass, decl, keys)
+	}
+	return nil, nil //nolint:nilnil
+}
+
+// checkField checks the copy tag of a struct field
+func (c *checker) checkField(pass *analysis.Pass, field *ast.Field) {
+	if field.Tag == nil {
+		return
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return
+	}
+	tagValue, ok := reflect.StructTag(tag).Lookup(c.tagName)
+	if !ok {
+		return
+	}
+
+	if strings.Count(tagValue, "'")%2 != 0 {
+		pass.Reportf(field.Tag.Pos(), "unterminated quote in %s tag", c.tagName)
+	}
+	tags := splitTag(tagValue)
+	if tags[0] == "-" && len(tags) > 1 {
+		pass.Reportf(field.Tag.Pos(), "%s tag options of ignored field have no effect", c.tagName)
+	}
+	seen := map[string]bool{}
+	for _, tagOpt := range tags[1:] {
+		optName, optValue, hasValue := cutTagOption(tagOpt)
+		if optName == "" {
+			pass.Reportf(field.Tag.Pos(), "empty %s tag option", c.tagName)
+			continue
+		}
+		if seen[optName] {
+			pass.Reportf(field.Tag.Pos(), "duplicate %s tag option %q", c.tagName, optName)
+			continue
+		}
+		seen[optName] = true
+
+		switch {
+		case flagOptions[optName]:
+			if hasValue {
+				pass.Reportf(field.Tag.Pos(), "%s tag option %q takes no value", c.tagName, optName)
+			}
+			if optName == "nilonzero" {
+				checkNilOnZero(pass, field)
+			}
+		case optName == "layout":
+			if optValue == "" {
+				pass.Reportf(field.Tag.Pos(), "%s tag option %q requires a value", c.tagName, optName)
+			}
+		case optName == "unix":
+			if !unixUnits[optValue] {
+				pass.Reportf(field.Tag.Pos(), "%s tag option %q requires one of values s, ms, us, ns",
+					c.tagName, optName)
+			}
+		case optName == "strict":
+			if !isBlankField(field) {
+				pass.Reportf(field.Tag.Pos(), "%s tag option %q only applies to blank fields", c.tagName, optName)
+			}
+			if !strictModes[optValue] {
+				pass.Reportf(field.Tag.Pos(), "%s tag option %q requires one of values all, src, dst, none",
+					c.tagName, optName)
+			}
+		default:
+			if suggestion := suggestOption(optName); suggestion != "" {
+				pass.Reportf(field.Tag.Pos(), "unknown %s tag option %q, did you mean %q?",
+					c.tagName, optName, suggestion)
+			} else {
+				pass.Reportf(field.Tag.Pos(), "unknown %s tag option %q", c.tagName, optName)
+			}
+		}
+	}
+}
+
+// splitTag splits a tag value into the key and options separated by commas,
+// commas within single quotes don't separate options
+func splitTag(tagValue string) []string {
+	tags := make([]string, 0, strings.Count(tagValue, ",")+1)
+	start, quoted := 0, false
+	for i := 0; i < len(tagValue); i++ {
+		switch tagValue[i] {
+		case '\'':
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				tags = append(tags, tagValue[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(tags, tagValue[start:])
+}
+
+// cutTagOption splits a tag option into its name and value, single quotes around the value are removed
+func cutTagOption(tagOpt string) (name, value string, hasValue bool) {
+	name, value, hasValue = strings.Cut(tagOpt, "=")
+	if n := len(value); n >= 2 && value[0] == '\'' && value[n-1] == '\'' {
+		value = value[1 : n-1]
+	}
+	return name, value, hasValue
+}
+
+// isBlankField checks if the field is a blank field `_`
+func isBlankField(field *ast.Field) bool {
+	return len(field.Names) == 1 && field.Names[0].Name == "_"
+}
+
+// checkNilOnZero checks the field having tag option `nilonzero` is of a nillable type
+func checkNilOnZero(pass *analysis.Pass, field *ast.Field) {
+	typ := pass.TypesInfo.TypeOf(field.Type)
+	if typ == nil {
+		return
+	}
+	switch typ.Underlying().(type) {
+	case *types.Pointer, *types.Interface, *types.Slice, *types.Map:
+		return
+	}
+	pass.Reportf(field.Tag.Pos(), "tag option \"nilonzero\" has no effect on field of non-nillable type %s",
+		types.TypeString(typ, types.RelativeTo(pass.Pkg)))
+}
+
+// checkMethod checks signature and receiver of a copying method or the post-copy method of a struct type.
+// Only methods `Copy<Key>` the library looks up are checked, `<Key>` must be a field key of the struct
+// or of a struct copied to it in the package.
+func checkMethod(pass *analysis.Pass, decl *ast.FuncDecl, keys *copyingKeys) {
+	if decl.Recv == nil || !strings.HasPrefix(decl.Name.Name, methodPrefixCopy) &&
+		decl.Name.Name != methodPostCopy {
+		return
+	}
+	method, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+	if !ok {
+		return
+	}
+	sig, _ := method.Type().(*types.Signature)
+	recvType := sig.Recv().Type()
+	ptrRecv := false
+	if ptr, ok := recvType.(*types.Pointer); ok {
+		recvType, ptrRecv = ptr.Elem(), true
+	}
+	if _, ok := recvType.Underlying().(*types.Struct); !ok {
+		return
+	}
+
+	name := method.Name()
+	returnsErr := sig.Results().Len() == 1 && types.Identical(sig.Results().At(0).Type(), errType)
+	if name == methodPostCopy {
+		if sig.Params().Len() != 1 || !isEmptyInterface(sig.Params().At(0).Type()) || !returnsErr {
+			pass.Reportf(decl.Name.Pos(), "method %s is never called by deepcopy: signature must be func(any) error",
+				name)
+			return
+		}
+		if !ptrRecv {
+			pass.Reportf(decl.Name.Pos(), "method %s has a value receiver: changes made to the copy are lost", name)
+		}
+		return
+	}
+
+	// Methods not matching any field key are never looked up, they are likely not for deepcopy
+	named, ok := recvType.(*types.Named)
+	if !ok || !keys.has(named, name) {
+		return
+	}
+	switch {
+	case sig.Params().Len() == 1 && !returnsErr:
+		pass.Reportf(decl.Name.Pos(), "copying method %s is never called by deepcopy: it must return only error",
+			name)
+	case sig.Params().Len() != 1 && returnsErr:
+		pass.Reportf(decl.Name.Pos(),
+			"copying method %s is never called by deepcopy: it must take exactly one argument", name)
+	case sig.Params().Len() == 1 && !ptrRecv:
+		pass.Reportf(decl.Name.Pos(), "copying method %s has a value receiver: changes made to the copy are lost",
+			name)
+	}
+}
+
+// isEmptyInterface checks if the type is `any` or `interface{}`
+func isEmptyInterface(typ types.Type) bool {
+	iface, ok := types.Unalias(typ).(*types.Interface)
+	return ok && iface.Empty()
+}
+
+// suggestOption returns the known tag option closest to the unknown one, returns empty if none is close enough
+func suggestOption(optName string) string {
+	suggestion, minDistance := "", maxSuggestionDistance+1
+	for _, known := range knownOptions {
+		if d := editDistance(strings.ToLower(optName), known); d < minDistance {
+			suggestion, minDistance = known, d
+		}
+	}
+	return suggestion
+}
+
+// editDistance returns Levenshtein distance of two strings
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,31 @@
+package copycheck
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func Test_Analyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "a")
+}
+
+func Test_NewAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), NewAnalyzer("dto"), "b")
+}
+
+func Test_suggestOption(t *testing.T) {
+	tests := map[string]string{
+		"requried":  "required",
+		"nilOnZero": "nilonzero",
+		"shalow":    "shallow",
+		"unx":       "unix",
+		"xyz":       "",
+		"stict":     "strict",
+		"omitempty": "",
+	}
+	for opt, expected := range tests {
+		assert.Equal(t, expected, suggestOption(opt), opt)
+	}
+}
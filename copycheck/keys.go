@@ -0,0 +1,157 @@
+package copycheck
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// copyingKeys collects names of copying methods the library looks up on struct types of a package,
+// they are `Copy<Key>` with keys of fields of the struct and of the source structs copied to it
+type copyingKeys struct {
+	tagName string
+	keys    map[*types.TypeName]map[string]bool
+}
+
+// has checks if the method of the struct type is looked up by the library
+func (k *copyingKeys) has(named *types.Named, methodName string) bool {
+	obj := named.Origin().Obj()
+	if _, found := k.keys[obj]; !found {
+		k.addKeys(obj, fieldTypes(named, k.tagName, 0))
+	}
+	return k.keys[obj][methodName]
+}
+
+// addKeys adds method names of the keys to the struct type
+func (k *copyingKeys) addKeys(obj *types.TypeName, fields map[string]types.Type) {
+	methods := k.keys[obj]
+	if methods == nil {
+		methods = map[string]bool{}
+		k.keys[obj] = methods
+	}
+	for key := range fields {
+		methods[methodPrefixCopy+upperFirst(key)] = true
+	}
+}
+
+// addCall collects keys of source structs of a call to `deepcopy.Copy` or `deepcopy.CopyValue`
+func (k *copyingKeys) addCall(pass *analysis.Pass, call *ast.CallExpr) {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != deepcopyPkgPath || !copyFuncs[fn.Name()] ||
+		len(call.Args) < 2 { //nolint:mnd
+		return
+	}
+	dstType, srcType := pass.TypesInfo.TypeOf(call.Args[0]), pass.TypesInfo.TypeOf(call.Args[1])
+	if dstType == nil || srcType == nil {
+		return
+	}
+	k.pair(pass.Pkg, dstType, srcType, 0)
+}
+
+// pair collects keys of the source type for the destination type and their nested types
+func (k *copyingKeys) pair(pkg *types.Package, dstType, srcType types.Type, depth int) {
+	if depth > maxPairDepth {
+		return
+	}
+	dstType, srcType = deref(dstType), deref(srcType)
+	switch dst := dstType.Underlying().(type) {
+	case *types.Struct:
+		if _, ok := srcType.Underlying().(*types.Struct); !ok {
+			return
+		}
+		srcFields := fieldTypes(srcType, k.tagName, 0)
+		if named, ok := dstType.(*types.Named); ok && named.Obj().Pkg() == pkg {
+			k.addKeys(named.Origin().Obj(), srcFields)
+		}
+		dstFields := fieldTypes(dstType, k.tagName, 0)
+		for key, srcField := range srcFields {
+			if dstField, found := dstFields[key]; found {
+				k.pair(pkg, dstField, srcField, depth+1)
+			}
+		}
+	case *types.Slice:
+		if elem := elemType(srcType); elem != nil {
+			k.pair(pkg, dst.Elem(), elem, depth+1)
+		}
+	case *types.Array:
+		if elem := elemType(srcType); elem != nil {
+			k.pair(pkg, dst.Elem(), elem, depth+1)
+		}
+	case *types.Map:
+		if src, ok := srcType.Underlying().(*types.Map); ok {
+			k.pair(pkg, dst.Elem(), src.Elem(), depth+1)
+		}
+	}
+}
+
+// fieldTypes returns types of struct fields by their keys including fields inherited from embedded structs,
+// direct fields have higher priority than inherited ones as the library does
+func fieldTypes(typ types.Type, tagName string, depth int) map[string]types.Type {
+	st, ok := deref(typ).Underlying().(*types.Struct)
+	if !ok || depth > maxPairDepth {
+		return nil
+	}
+	fields := make(map[string]types.Type, st.NumFields())
+	var embedded []*types.Var
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		key := field.Name()
+		if tagValue, ok := reflect.StructTag(st.Tag(i)).Lookup(tagName); ok {
+			tags := splitTag(tagValue)
+			if tags[0] == "-" {
+				continue
+			}
+			if tags[0] != "" {
+				key = tags[0]
+			}
+		}
+		if field.Embedded() {
+			embedded = append(embedded, field)
+		}
+		fields[key] = field.Type()
+	}
+	for _, field := range embedded {
+		for key, typ := range fieldTypes(field.Type(), tagName, depth+1) {
+			if _, found := fields[key]; !found {
+				fields[key] = typ
+			}
+		}
+	}
+	return fields
+}
+
+// deref returns the type pointed to by pointer types
+func deref(typ types.Type) types.Type {
+	for {
+		ptr, ok := types.Unalias(typ).(*types.Pointer)
+		if !ok {
+			return types.Unalias(typ)
+		}
+		typ = ptr.Elem()
+	}
+}
+
+// elemType returns element type of slice and array types, returns nil otherwise
+func elemType(typ types.Type) types.Type {
+	switch t := typ.Underlying().(type) {
+	case *types.Slice:
+		return t.Elem()
+	case *types.Array:
+		return t.Elem()
+	}
+	return nil
+}
+
+// upperFirst returns the key with its first letter in upper case
+func upperFirst(key string) string {
+	r, size := utf8.DecodeRuneInString(key)
+	if r == utf8.RuneError {
+		return key
+	}
+	return string(unicode.ToUpper(r)) + key[size:]
+}
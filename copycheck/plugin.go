@@ -0,0 +1,42 @@
+package copycheck
+
+import (
+	"github.com/golangci/plugin-module-register/register"
+	"golang.org/x/tools/go/analysis"
+)
+
+func init() {
+	register.Plugin("copycheck", newPlugin)
+}
+
+// Settings settings of the golangci-lint plugin
+type Settings struct {
+	// Tag name of struct tag to check, default is `copy`
+	Tag string `json:"tag"`
+}
+
+// plugin golangci-lint module plugin of the analyzer
+type plugin struct {
+	settings Settings
+}
+
+func newPlugin(conf any) (register.LinterPlugin, error) {
+	settings, err := register.DecodeSettings[Settings](conf)
+	if err != nil {
+		return nil, err
+	}
+	if settings.Tag == "" {
+		settings.Tag = DefaultTagName
+	}
+	return &plugin{settings: settings}, nil
+}
+
+// BuildAnalyzers implements register.LinterPlugin
+func (p *plugin) BuildAnalyzers() ([]*analysis.Analyzer, error) {
+	return []*analysis.Analyzer{NewAnalyzer(p.settings.Tag)}, nil
+}
+
+// GetLoadMode implements register.LinterPlugin
+func (p *plugin) GetLoadMode() string {
+	return register.LoadModeTypesInfo
+}
@@ -0,0 +1,128 @@
+package a
+
+import (
+	"io"
+	"time"
+
+	"github.com/tiendc/go-deepcopy"
+)
+
+type Any interface{}
+
+type Tags struct {
+	A int       `copy:"a,required"`
+	B int       `copy:",requried"`        // want `unknown copy tag option "requried", did you mean "required"\?`
+	C int       `copy:",foo"`             // want `unknown copy tag option "foo"`
+	D int       `copy:"-,required"`       // want `copy tag options of ignored field have no effect`
+	E int       `copy:",shallow,shallow"` // want `duplicate copy tag option "shallow"`
+	F int       `copy:",,shallow"`        // want `empty copy tag option`
+	G int       `copy:",required=true"`   // want `copy tag option "required" takes no value`
+	H int       `copy:",nilonzero"`       // want `tag option "nilonzero" has no effect on field of non-nillable type int`
+	I *int      `copy:",nilonzero"`
+	J []int     `copy:",nilonzero"`
+	K Any       `copy:",nilonzero"`
+	L string    `copy:",layout"` // want `copy tag option "layout" requires a value`
+	M string    `copy:",layout=RFC3339,utc"`
+	T string    `copy:",layout='Mon, 02 Jan 2006',utc"`
+	U string    `copy:",layout=''"`                // want `copy tag option "layout" requires a value`
+	V string    `copy:",layout='Mon, 02 Jan 2006"` // want `unterminated quote in copy tag`
+	N int64     `copy:",unix=sec"`                 // want `copy tag option "unix" requires one of values s, ms, us, ns`
+	O int64     `copy:",unix=ms"`
+	P time.Time `json:"p,requried"`
+	_ struct{}  `copy:",strict=dst"`
+	_ struct{}  `copy:",strict=both"` // want `copy tag option "strict" requires one of values all, src, dst, none`
+	S int       `copy:",strict"`      // want `copy tag option "strict" only applies to blank fields`
+	Q struct {
+		R int `copy:",NilOnZero"` // want `unknown copy tag option "NilOnZero", did you mean "nilonzero"\?`
+	}
+}
+
+type Methods struct {
+	a, b, c, d int
+	E          []int
+}
+
+func (m *Methods) CopyA(v int) error { return nil }
+
+func (m *Methods) CopyB(v int) {} // want `copying method CopyB is never called by deepcopy: it must return only error`
+
+func (m *Methods) CopyC(a, b int) error { return nil } // want `copying method CopyC is never called by deepcopy: it must take exactly one argument`
+
+func (m Methods) CopyD(v int) error { return nil } // want `copying method CopyD has a value receiver: changes made to the copy are lost`
+
+func (m *Methods) CopyE(v ...int) error { return nil }
+
+func (m *Methods) Copy() *Methods { return m }
+
+func (m *Methods) CopyTo(dst *Methods, deep bool) {}
+
+func (m *Methods) PostCopy(src any) error { return nil }
+
+type PostCopyValue struct{}
+
+func (p PostCopyValue) PostCopy(src interface{}) error { return nil } // want `method PostCopy has a value receiver: changes made to the copy are lost`
+
+type PostCopyWrongArg struct{}
+
+func (p *PostCopyWrongArg) PostCopy(src *PostCopyValue) error { return nil } // want `method PostCopy is never called by deepcopy: signature must be func\(any\) error`
+
+type PostCopyNamedArg struct{}
+
+func (p *PostCopyNamedArg) PostCopy(src Any) error { return nil } // want `method PostCopy is never called by deepcopy: signature must be func\(any\) error`
+
+type PostCopyNoErr struct{}
+
+func (p *PostCopyNoErr) PostCopy(src any) {} // want `method PostCopy is never called by deepcopy: signature must be func\(any\) error`
+
+type NotStruct int
+
+func (n NotStruct) CopyX(v int) {}
+
+func (n NotStruct) PostCopy(src any) error { return nil }
+
+// Methods not matching any key are not for deepcopy
+
+type File struct {
+	Path string
+}
+
+func (f *File) CopyFile(src, dst string) error { return nil }
+
+func (f File) CopyTo(w io.Writer) error { return nil }
+
+func (f *File) CopyPath(src, dst string) error { return nil } // want `copying method CopyPath is never called by deepcopy: it must take exactly one argument`
+
+// Keys of source structs copied in the package
+
+type Src struct {
+	Name  string
+	Items []SrcItem
+	Inner *SrcItem `copy:"inner"`
+}
+
+type SrcItem struct {
+	Value int
+}
+
+type Dst struct {
+	Items []DstItem
+	Inner DstItem `copy:"inner"`
+}
+
+type DstItem struct{}
+
+func (d *Dst) CopyName(v string) {} // want `copying method CopyName is never called by deepcopy: it must return only error`
+
+func (d DstItem) CopyValue(v int) error { return nil } // want `copying method CopyValue has a value receiver: changes made to the copy are lost`
+
+type Other struct{}
+
+func (o *Other) CopyName(v string) {}
+
+func copyDst(src *Src) (*Dst, error) {
+	var dst Dst
+	if err := deepcopy.Copy(&dst, src); err != nil {
+		return nil, err
+	}
+	return &dst, nil
+}
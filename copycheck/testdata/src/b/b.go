@@ -0,0 +1,6 @@
+package b
+
+type Tags struct {
+	A int `dto:",requried"` // want `unknown dto tag option "requried", did you mean "required"\?`
+	B int `copy:",requried"`
+}
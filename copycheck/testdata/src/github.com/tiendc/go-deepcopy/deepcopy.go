@@ -0,0 +1,7 @@
+package deepcopy
+
+type Option func()
+
+func Copy(dst, src any, options ...Option) error { return nil }
+
+func CopyValue[D, S any](dst *D, src *S) error { return nil }
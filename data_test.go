@@ -19,6 +19,13 @@ func ptrOf[T any](v T) *T {
 	return &v
 }
 
+// testPreparedContext returns a default context prepared for copiers
+func testPreparedContext() *Context {
+	ctx := defaultContext()
+	_ = ctx.prepare()
+	return ctx
+}
+
 var (
 	errTest = errors.New("err test")
 )
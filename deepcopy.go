@@ -4,13 +4,71 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
-	"sync"
 )
 
 const (
 	DefaultTagName = "copy"
 )
 
+// ChanPolicy policy of copying channels
+type ChanPolicy uint8
+
+const (
+	// ChanPolicyNonCopyable channels are non-copyable, copying them results in ErrTypeNonCopyable
+	ChanPolicyNonCopyable ChanPolicy = iota
+	// ChanPolicyShare destination channels share the source channels
+	ChanPolicyShare
+	// ChanPolicyNew destination channels are new empty channels having the same capacity as the source ones
+	ChanPolicyNew
+	// ChanPolicyNil destination channels are left as `nil`
+	ChanPolicyNil
+)
+
+// UnsafePolicy policy of copying `unsafe.Pointer` and `uintptr` values
+type UnsafePolicy uint8
+
+const (
+	// UnsafePolicyDefault `uintptr` is copied as is, `unsafe.Pointer` is non-copyable
+	UnsafePolicyDefault UnsafePolicy = iota
+	// UnsafePolicyShare all are copied as is, the destination shares the memory pointed by the source
+	UnsafePolicyShare
+	// UnsafePolicyZero all are left zeroed in the destination
+	UnsafePolicyZero
+	// UnsafePolicyError all are non-copyable, copying them results in ErrTypeNonCopyable
+	UnsafePolicyError
+)
+
+// TextConv conversions of values from and to text, they can be combined
+type TextConv uint8
+
+const (
+	// TextConvMarshaler convert via `encoding.TextMarshaler` of source types and
+	// `encoding.TextUnmarshaler` of destination types
+	TextConvMarshaler TextConv = 1 << iota
+	// TextConvFlagValue convert text via `flag.Value` of destination types
+	TextConvFlagValue
+	// TextConvStringer convert via `fmt.Stringer` of source types
+	TextConvStringer
+	// TextConvNone no conversions from and to text
+	TextConvNone TextConv = 0
+	// TextConvAll all conversions from and to text
+	TextConvAll = TextConvMarshaler | TextConvFlagValue | TextConvStringer
+)
+
+// StrictMode checks of unmatched exported fields when copying structs, they can be combined
+type StrictMode uint8
+
+const (
+	// StrictModeSrc every exported source field must be copied to a destination field or method
+	StrictModeSrc StrictMode = 1 << iota
+	// StrictModeDst every exported destination field must be copied from a source field
+	StrictModeDst
+	// StrictModeNone no checks of unmatched fields
+	StrictModeNone StrictMode = 0
+	// StrictModeAll checks of both source fields and destination fields
+	StrictModeAll = StrictModeSrc | StrictModeDst
+)
+
 var (
 	// defaultTagName default tag name for the program to parse input struct tags
 	// to build copier configuration.
@@ -28,13 +86,76 @@ type Context struct {
 	// IgnoreNonCopyableTypes ignore non-copyable types (default is `false`)
 	IgnoreNonCopyableTypes bool
 
+	// CopyViaDeepCopyMethod allow or not copying values to the same type via the type's own
+	// deep copy methods `DeepCopyInto`, `DeepCopy` or `Clone` (default is `false`)
+	CopyViaDeepCopyMethod bool
+
+	// CopyIntoIfaceValue allow or not copying into the values held by destination interfaces when they are
+	// non-nil pointers or maps, instead of replacing them with clones of the source values (default is `false`)
+	CopyIntoIfaceValue bool
+
 	// UseGlobalCache if false not use global cache (default is `true`)
 	UseGlobalCache bool
 
-	// copierCacheMap cache to speed up parsing types
-	copierCacheMap map[cacheKey]copier
-	mu             *sync.RWMutex
-	flags          uint8
+	// UseRegisteredCopyFuncs allow or not copying via functions registered by RegisterCopyFunc
+	// such as ones generated by `cmd/deepcopy-gen` (default is `true`)
+	UseRegisteredCopyFuncs bool
+
+	// MaxDepth max nesting level of values to copy, 0 means unlimited (default is `0`).
+	// Every pointer, interface, slice, array, map and struct on the way counts as one level.
+	MaxDepth int
+
+	// IgnoreDepthExceeded leave values nested deeper than MaxDepth zeroed instead of
+	// returning ErrDepthExceeded (default is `false`)
+	IgnoreDepthExceeded bool
+
+	// OnlyFields paths of the only fields to copy, e.g. `Name`, `Address.City` (default is `nil`).
+	// Paths are made of copy keys and apply through nested structs, pointers, slices and maps.
+	OnlyFields []string
+
+	// OmitFields paths of the fields to skip copying (default is `nil`)
+	OmitFields []string
+
+	// ShallowCopyTypes types of values to be assigned as is without deep copying (default is `nil`)
+	ShallowCopyTypes []reflect.Type
+
+	// ChanCopyPolicy policy of copying channels (default is `ChanPolicyNonCopyable`)
+	ChanCopyPolicy ChanPolicy
+
+	// UnsafeCopyPolicy policy of copying `unsafe.Pointer` and `uintptr` values, it applies to values
+	// copied from and to them (default is `UnsafePolicyDefault`)
+	UnsafeCopyPolicy UnsafePolicy
+
+	// OpaqueTypes types of opaque handles which are never looked inside, they are always copied
+	// by value regardless of UnsafeCopyPolicy (default is `nil`)
+	OpaqueTypes []reflect.Type
+
+	// TextConversion conversions between text (`string`, `[]byte`) and types implementing
+	// text interfaces such as `encoding.TextMarshaler` (default is `TextConvNone`)
+	TextConversion TextConv
+
+	// TimeConversion allow or not converting `time.Time` and `time.Duration` values from and to strings
+	// when they have no time tag options such as `layout` (default is `false`)
+	TimeConversion bool
+
+	// StrictMatching checks of unmatched exported fields when copying structs, copying fails with
+	// ErrFieldUnmatched listing all unmatched fields (default is `StrictModeNone`).
+	// A struct can override it with tag option `strict` of a blank field `_` such as `copy:",strict=dst"`.
+	StrictMatching StrictMode
+
+	// ParallelWorkers max number of goroutines copying items of a large slice or map, slices and maps
+	// nested in items copied in parallel are not copied in parallel again.
+	// 0 or 1 means copying in the calling goroutine only (default is `0`)
+	ParallelWorkers int
+
+	// ParallelMinChunk min number of items copied by a goroutine, slices and maps having fewer than
+	// twice this number of items are copied in the calling goroutine (default is `1024`)
+	ParallelMinChunk int
+
+	// copierCache cache to speed up parsing types
+	copierCache *copierCache
+	// copierOptions interned settings of building copiers, set by `prepare`
+	*copierOptions
 }
 
 // Option configuration option function provided as extra arguments of copying function
@@ -62,6 +183,20 @@ func CopyViaCopyingMethod(flag bool) Option {
 	}
 }
 
+// CopyViaDeepCopyMethod config function for setting flag `CopyViaDeepCopyMethod`
+func CopyViaDeepCopyMethod(flag bool) Option {
+	return func(ctx *Context) {
+		ctx.CopyViaDeepCopyMethod = flag
+	}
+}
+
+// CopyIntoIfaceValue config function for setting flag `CopyIntoIfaceValue`
+func CopyIntoIfaceValue(flag bool) Option {
+	return func(ctx *Context) {
+		ctx.CopyIntoIfaceValue = flag
+	}
+}
+
 // IgnoreNonCopyableTypes config function for setting flag `IgnoreNonCopyableTypes`
 func IgnoreNonCopyableTypes(flag bool) Option {
 	return func(ctx *Context) {
@@ -76,6 +211,108 @@ func UseGlobalCache(flag bool) Option {
 	}
 }
 
+// UseRegisteredCopyFuncs config function for setting flag `UseRegisteredCopyFuncs`
+func UseRegisteredCopyFuncs(flag bool) Option {
+	return func(ctx *Context) {
+		ctx.UseRegisteredCopyFuncs = flag
+	}
+}
+
+// MaxDepth config function for setting `MaxDepth`
+func MaxDepth(n int) Option {
+	return func(ctx *Context) {
+		ctx.MaxDepth = n
+	}
+}
+
+// IgnoreDepthExceeded config function for setting flag `IgnoreDepthExceeded`
+func IgnoreDepthExceeded(flag bool) Option {
+	return func(ctx *Context) {
+		ctx.IgnoreDepthExceeded = flag
+	}
+}
+
+// Only config function for adding paths of the only fields to copy.
+// A path is made of copy keys separated by dots, e.g. `Only("Name", "Address.City", "Items.Price")`.
+func Only(paths ...string) Option {
+	return func(ctx *Context) {
+		ctx.OnlyFields = append(ctx.OnlyFields, paths...)
+	}
+}
+
+// Omit config function for adding paths of the fields to skip copying.
+// A path is made of copy keys separated by dots, e.g. `Omit("Password", "Items.Cost")`.
+func Omit(paths ...string) Option {
+	return func(ctx *Context) {
+		ctx.OmitFields = append(ctx.OmitFields, paths...)
+	}
+}
+
+// ShallowCopyType config function for adding a type to `ShallowCopyTypes`.
+// Values of the type, e.g. `ShallowCopyType[*regexp.Regexp]()`, will be assigned as is without deep copying.
+func ShallowCopyType[T any]() Option {
+	return func(ctx *Context) {
+		ctx.ShallowCopyTypes = append(ctx.ShallowCopyTypes, typeOf[T]())
+	}
+}
+
+// ChanCopyPolicy config function for setting `ChanCopyPolicy`
+func ChanCopyPolicy(policy ChanPolicy) Option {
+	return func(ctx *Context) {
+		ctx.ChanCopyPolicy = policy
+	}
+}
+
+// UnsafeCopyPolicy config function for setting `UnsafeCopyPolicy`
+func UnsafeCopyPolicy(policy UnsafePolicy) Option {
+	return func(ctx *Context) {
+		ctx.UnsafeCopyPolicy = policy
+	}
+}
+
+// OpaqueType config function for adding a type to `OpaqueTypes`.
+// Values of the type, e.g. `OpaqueType[C.Handle]()`, will always be copied by value.
+func OpaqueType[T any]() Option {
+	return func(ctx *Context) {
+		ctx.OpaqueTypes = append(ctx.OpaqueTypes, typeOf[T]())
+	}
+}
+
+// TextConversion config function for setting `TextConversion`
+func TextConversion(conv TextConv) Option {
+	return func(ctx *Context) {
+		ctx.TextConversion = conv
+	}
+}
+
+// TimeConversion config function for setting flag `TimeConversion`
+func TimeConversion(flag bool) Option {
+	return func(ctx *Context) {
+		ctx.TimeConversion = flag
+	}
+}
+
+// StrictMatching config function for setting `StrictMatching`
+func StrictMatching(mode StrictMode) Option {
+	return func(ctx *Context) {
+		ctx.StrictMatching = mode
+	}
+}
+
+// Parallel config function for setting `ParallelWorkers` and `ParallelMinChunk`.
+// Items of slices and arrays, and entries of maps are split into chunks of at least `minChunk` items
+// which are copied by up to `workers` goroutines, e.g. `Parallel(runtime.NumCPU(), 10000)`.
+// Slices and maps nested in items copied in parallel are copied by the goroutines of the items.
+// When copying a slice or an array fails, the error of the failed item having the lowest index is returned.
+// When copying a map fails, the error of the failed entry having the smallest key is returned, keys of kinds
+// having no order such as structs are compared by their formatted texts.
+func Parallel(workers, minChunk int) Option {
+	return func(ctx *Context) {
+		ctx.ParallelWorkers = workers
+		ctx.ParallelMinChunk = minChunk
+	}
+}
+
 // Copy performs deep copy from `src` to `dst`.
 //
 // `dst` must be a pointer to the output var, `src` can be either value or pointer.
@@ -98,7 +335,9 @@ func Copy(dst, src any, options ...Option) (err error) {
 	for _, opt := range options {
 		opt(ctx)
 	}
-	ctx.prepare()
+	if err = ctx.prepare(); err != nil {
+		return err
+	}
 
 	cp, err := buildCopier(ctx, dstType, srcType)
 	if err != nil {
@@ -107,11 +346,10 @@ func Copy(dst, src any, options ...Option) (err error) {
 	return cp.Copy(dstVal, srcVal)
 }
 
-// ClearCache clears global cache of previously used copiers
+// ClearCache clears global cache of previously used copiers.
+// Copiers being built at the moment are kept, so it's safe to call it while copying.
 func ClearCache() {
-	mu.Lock()
-	copierCacheMap = map[cacheKey]copier{}
-	mu.Unlock()
+	globalCopierCache.clear()
 }
 
 // SetDefaultTagName overwrites the default tag name.
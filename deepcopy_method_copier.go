@@ -0,0 +1,76 @@
+package deepcopy
+
+import (
+	"reflect"
+)
+
+// deepCopyMethodKind kind of deep copy methods defined within types
+type deepCopyMethodKind uint8
+
+const (
+	// deepCopyMethodInto method such as `DeepCopyInto(out *T)`
+	deepCopyMethodInto deepCopyMethodKind = iota
+	// deepCopyMethodRetPtr method such as `DeepCopy() *T` or `Clone() *T`
+	deepCopyMethodRetPtr
+	// deepCopyMethodRetValue method such as `DeepCopy() T` or `Clone() T`
+	deepCopyMethodRetValue
+)
+
+// buildCopierForDeepCopyMethods builds copier that copies values of the type via its own deep copy methods.
+// Methods are looked for in order: `DeepCopyInto(out *T)`, `DeepCopy() *T` or `DeepCopy() T`,
+// `Clone() *T` or `Clone() T`. Returns nil if the type has none of them.
+func buildCopierForDeepCopyMethods(typ reflect.Type) copier {
+	ptrType := reflect.PointerTo(typ)
+
+	if method, ok := ptrType.MethodByName(typeMethodDeepCopyInto); ok {
+		if method.Type.NumIn() == 2 && method.Type.NumOut() == 0 && method.Type.In(1) == ptrType {
+			return &deepCopyMethodCopier{method: method.Index, kind: deepCopyMethodInto}
+		}
+	}
+
+	for _, methodName := range []string{typeMethodDeepCopy, typeMethodClone} {
+		method, ok := ptrType.MethodByName(methodName)
+		if !ok || method.Type.NumIn() != 1 || method.Type.NumOut() != 1 {
+			continue
+		}
+		switch method.Type.Out(0) {
+		case ptrType:
+			return &deepCopyMethodCopier{method: method.Index, kind: deepCopyMethodRetPtr}
+		case typ:
+			return &deepCopyMethodCopier{method: method.Index, kind: deepCopyMethodRetValue}
+		}
+	}
+	return nil
+}
+
+// deepCopyMethodCopier data structure of copier that copies values via deep copy methods of their type
+type deepCopyMethodCopier struct {
+	method int
+	kind   deepCopyMethodKind
+}
+
+// Copy implementation of Copy function for deep copy method copier
+func (c *deepCopyMethodCopier) Copy(dst, src reflect.Value) error {
+	// NOTE: methods are looked for in the pointer type, so the source must be addressable
+	if !src.CanAddr() {
+		srcCopy := reflect.New(src.Type()).Elem()
+		srcCopy.Set(src)
+		src = srcCopy
+	}
+	method := src.Addr().Method(c.method)
+
+	switch c.kind {
+	case deepCopyMethodInto:
+		method.Call([]reflect.Value{dst.Addr()})
+	case deepCopyMethodRetPtr:
+		ret := method.Call(nil)[0]
+		if ret.IsNil() {
+			dst.Set(reflect.Zero(dst.Type())) // NOTE: Go1.18 has no SetZero
+			return nil
+		}
+		dst.Set(ret.Elem())
+	case deepCopyMethodRetValue:
+		dst.Set(method.Call(nil)[0])
+	}
+	return nil
+}
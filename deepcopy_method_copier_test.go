@@ -0,0 +1,127 @@
+package deepcopy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testIntoObj has method `DeepCopyInto` and `DeepCopy` like Kubernetes types
+type testIntoObj struct {
+	Name  string
+	Items []int
+	calls *int
+}
+
+func (in *testIntoObj) DeepCopyInto(out *testIntoObj) {
+	*out = *in
+	out.Name = in.Name + "(into)"
+	out.Items = append([]int(nil), in.Items...)
+	*in.calls++
+}
+
+func (in *testIntoObj) DeepCopy() *testIntoObj {
+	panic("should not be called as DeepCopyInto has higher priority")
+}
+
+// testPtrObj has method `DeepCopy() *T`
+type testPtrObj struct {
+	Name string
+}
+
+func (in *testPtrObj) DeepCopy() *testPtrObj {
+	if in.Name == "nil" {
+		return nil
+	}
+	return &testPtrObj{Name: in.Name + "(deepcopy)"}
+}
+
+// testLabels has method `Clone() T` with value receiver
+type testLabels map[string]string
+
+func (in testLabels) Clone() testLabels {
+	if in == nil {
+		return nil
+	}
+	out := make(testLabels, len(in)+1)
+	for k, v := range in {
+		out[k] = v
+	}
+	out["cloned"] = "true"
+	return out
+}
+
+// testInvalidObj has methods not matching the conventions
+type testInvalidObj struct {
+	Name string
+}
+
+func (in *testInvalidObj) DeepCopyInto(out *testPtrObj) {}
+func (in *testInvalidObj) DeepCopy() testPtrObj         { return testPtrObj{} }
+func (in *testInvalidObj) Clone(deep bool) string       { return "" }
+
+func Test_Copy_deepCopyMethods(t *testing.T) {
+	t.Run("#1: DeepCopyInto", func(t *testing.T) {
+		calls := 0
+		s := []testIntoObj{{Name: "a", Items: []int{1, 2}, calls: &calls}, {Name: "b", calls: &calls}}
+		var d []testIntoObj
+		err := Copy(&d, s, CopyViaDeepCopyMethod(true))
+		assert.Nil(t, err)
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, "a(into)", d[0].Name)
+		assert.Equal(t, []int{1, 2}, d[0].Items)
+		assert.Equal(t, "b(into)", d[1].Name)
+		d[0].Items[0] = 100
+		assert.Equal(t, 1, s[0].Items[0])
+	})
+
+	t.Run("#2: DeepCopy returning pointer", func(t *testing.T) {
+		type SS struct {
+			O1 testPtrObj
+			O2 *testPtrObj
+			O3 *testPtrObj
+			O4 testPtrObj
+		}
+		var d SS
+		err := Copy(&d, SS{O1: testPtrObj{Name: "a"}, O2: &testPtrObj{Name: "b"},
+			O4: testPtrObj{Name: "nil"}}, CopyViaDeepCopyMethod(true))
+		assert.Nil(t, err)
+		assert.Equal(t, SS{O1: testPtrObj{Name: "a(deepcopy)"}, O2: &testPtrObj{Name: "b(deepcopy)"}}, d)
+	})
+
+	t.Run("#3: Clone returning value", func(t *testing.T) {
+		type SS struct {
+			L1 testLabels
+			L2 testLabels
+		}
+		var d SS
+		err := Copy(&d, SS{L1: testLabels{"a": "b"}}, CopyViaDeepCopyMethod(true))
+		assert.Nil(t, err)
+		assert.Equal(t, SS{L1: testLabels{"a": "b", "cloned": "true"}}, d)
+	})
+
+	t.Run("#4: methods not matching the conventions", func(t *testing.T) {
+		var d testInvalidObj
+		err := Copy(&d, testInvalidObj{Name: "a"}, CopyViaDeepCopyMethod(true))
+		assert.Nil(t, err)
+		assert.Equal(t, testInvalidObj{Name: "a"}, d)
+	})
+
+	t.Run("#5: methods are not used by default, for different types or with field masks", func(t *testing.T) {
+		var d testPtrObj
+		err := Copy(&d, testPtrObj{Name: "a"})
+		assert.Nil(t, err)
+		assert.Equal(t, testPtrObj{Name: "a"}, d)
+
+		type PtrObj testPtrObj
+		var d2 PtrObj
+		err = Copy(&d2, testPtrObj{Name: "a"}, CopyViaDeepCopyMethod(true))
+		assert.Nil(t, err)
+		assert.Equal(t, PtrObj{Name: "a"}, d2)
+
+		var d3 testPtrObj
+		err = Copy(&d3, testPtrObj{Name: "a"}, CopyViaDeepCopyMethod(true), Only("Name"))
+		assert.Nil(t, err)
+		assert.Equal(t, testPtrObj{Name: "a"}, d3)
+	})
+}
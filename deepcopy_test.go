@@ -1,6 +1,7 @@
 package deepcopy
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -60,7 +61,34 @@ func Test_Copy(t *testing.T) {
 
 func Test_ClearCache(t *testing.T) {
 	ClearCache()
-	assert.Equal(t, 0, len(copierCacheMap))
+	assert.Equal(t, 0, globalCopierCache.len())
+}
+
+func Test_Copy_concurrently(t *testing.T) {
+	type Node struct {
+		Value    int
+		Children []*Node
+		Attrs    map[string]any
+	}
+	src := &Node{Value: 1, Children: []*Node{{Value: 2, Attrs: map[string]any{"a": []any{1, "b"}}}}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				if i == 0 && j%10 == 0 {
+					ClearCache()
+				}
+				var dst Node
+				err := Copy(&dst, src)
+				assert.Nil(t, err)
+				assert.Equal(t, *src, dst)
+			}
+		}(i)
+	}
+	wg.Wait()
 }
 
 func Test_ConfigOption(t *testing.T) {
@@ -85,6 +113,118 @@ func Test_ConfigOption(t *testing.T) {
 	assert.Equal(t, false, ctx.UseGlobalCache)
 	UseGlobalCache(true)(ctx)
 	assert.Equal(t, true, ctx.UseGlobalCache)
+
+	MaxDepth(10)(ctx)
+	assert.Equal(t, 10, ctx.MaxDepth)
+	MaxDepth(0)(ctx)
+	assert.Equal(t, 0, ctx.MaxDepth)
+
+	IgnoreDepthExceeded(true)(ctx)
+	assert.Equal(t, true, ctx.IgnoreDepthExceeded)
+	IgnoreDepthExceeded(false)(ctx)
+	assert.Equal(t, false, ctx.IgnoreDepthExceeded)
+
+	Only("A", "B.C")(ctx)
+	Only("D")(ctx)
+	assert.Equal(t, []string{"A", "B.C", "D"}, ctx.OnlyFields)
+
+	Omit("A", "B.C")(ctx)
+	assert.Equal(t, []string{"A", "B.C"}, ctx.OmitFields)
+
+	ChanCopyPolicy(ChanPolicyNew)(ctx)
+	assert.Equal(t, ChanPolicyNew, ctx.ChanCopyPolicy)
+
+	UnsafeCopyPolicy(UnsafePolicyZero)(ctx)
+	assert.Equal(t, UnsafePolicyZero, ctx.UnsafeCopyPolicy)
+
+	CopyViaDeepCopyMethod(true)(ctx)
+	assert.Equal(t, true, ctx.CopyViaDeepCopyMethod)
+
+	CopyIntoIfaceValue(true)(ctx)
+	assert.Equal(t, true, ctx.CopyIntoIfaceValue)
+
+	Parallel(4, 100)(ctx)
+	assert.Equal(t, 4, ctx.ParallelWorkers)
+	assert.Equal(t, 100, ctx.ParallelMinChunk)
+
+	UseRegisteredCopyFuncs(false)(ctx)
+	assert.Equal(t, false, ctx.UseRegisteredCopyFuncs)
+
+	StrictMatching(StrictModeDst)(ctx)
+	assert.Equal(t, StrictModeDst, ctx.StrictMatching)
+
+	TextConversion(TextConvMarshaler | TextConvStringer)(ctx)
+	assert.Equal(t, TextConvMarshaler|TextConvStringer, ctx.TextConversion)
+}
+
+func Test_Copy_maxDepth(t *testing.T) {
+	type Node struct {
+		I    int
+		Next *Node
+	}
+
+	t.Run("#1: nesting within the limit", func(t *testing.T) {
+		// []any -> any -> []any -> any -> map[string]any -> any
+		s := []any{1, []any{2, map[string]any{"a": 3}}}
+		var d []any
+		err := Copy(&d, s, MaxDepth(6))
+		assert.Nil(t, err)
+		assert.Equal(t, s, d)
+	})
+
+	t.Run("#2: nesting exceeds the limit", func(t *testing.T) {
+		s := []any{1, []any{2, map[string]any{"a": 3}}}
+		var d []any
+		err := Copy(&d, s, MaxDepth(5))
+		assert.ErrorIs(t, err, ErrDepthExceeded)
+	})
+
+	t.Run("#3: nesting exceeds the limit with ignoring the error", func(t *testing.T) {
+		s := []any{1, []any{2, map[string]any{"a": 3}}}
+		var d []any
+		err := Copy(&d, s, MaxDepth(5), IgnoreDepthExceeded(true))
+		assert.Nil(t, err)
+		assert.Equal(t, []any{1, []any{2, map[string]any{"a": nil}}}, d)
+
+		err = Copy(&d, s, MaxDepth(4), IgnoreDepthExceeded(true))
+		assert.Nil(t, err)
+		assert.Equal(t, []any{1, []any{2, map[string]any(nil)}}, d)
+	})
+
+	t.Run("#4: recursive struct type", func(t *testing.T) {
+		s := Node{I: 1, Next: &Node{I: 2, Next: &Node{I: 3}}}
+		var d Node
+		// Node -> *Node -> Node -> *Node -> Node
+		err := Copy(&d, s, MaxDepth(5))
+		assert.Nil(t, err)
+		assert.Equal(t, s, d)
+
+		err = Copy(&d, s, MaxDepth(4))
+		assert.ErrorIs(t, err, ErrDepthExceeded)
+
+		d = Node{}
+		err = Copy(&d, s, MaxDepth(3), IgnoreDepthExceeded(true))
+		assert.Nil(t, err)
+		assert.Equal(t, Node{I: 1, Next: &Node{I: 2}}, d)
+	})
+
+	t.Run("#5: zero values deeper than the limit", func(t *testing.T) {
+		s := Node{I: 1, Next: &Node{I: 2}}
+		var d Node
+		err := Copy(&d, s, MaxDepth(3))
+		assert.Nil(t, err)
+		assert.Equal(t, s, d)
+	})
+
+	t.Run("#6: very deep interface nesting", func(t *testing.T) {
+		var s any = "leaf"
+		for i := 0; i < 1000; i++ {
+			s = map[string]any{"k": s}
+		}
+		var d any
+		err := Copy(&d, s, MaxDepth(100))
+		assert.ErrorIs(t, err, ErrDepthExceeded)
+	})
 }
 
 func Test_SetDefaultTagName(t *testing.T) {
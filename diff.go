@@ -0,0 +1,564 @@
+package deepcopy
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+// Difference a difference between values found by Diff
+type Difference struct {
+	// Path path of the values such as `Items[1].Name` or `Attrs[key]`, empty for the root values.
+	// Names of struct fields are the ones of `a`.
+	Path string
+	// A value of `a` at the path, nil if there is none
+	A any
+	// B value of `b` at the path, nil if there is none
+	B any
+}
+
+// String returns human-readable description of the difference
+func (d Difference) String() string {
+	return fmt.Sprintf("%s: %v != %v", d.Path, d.A, d.B)
+}
+
+// Equal checks if `a` is equivalent to `b` under the same mapping rules as copying `b` to `a`.
+// See Diff for details of the comparison.
+func Equal(a, b any, options ...Option) (bool, error) {
+	d, err := newDiffer(options)
+	if err != nil {
+		return false, err
+	}
+	d.stopOnFirst = true
+	if err = d.diffRoot(a, b); err != nil {
+		return false, err
+	}
+	return len(d.diffs) == 0, nil
+}
+
+// Diff returns differences between `a` and `b` under the same mapping rules as copying `b` to `a`:
+//   - struct fields are matched by copy keys, fields ignored by tag `-`, unmatched fields and fields
+//     copied via copying methods are not compared,
+//   - pointers are compared with values when `CopyBetweenPtrAndValue` is enabled, nil pointers
+//     are equivalent to zero values,
+//   - values of different types are compared after copying values of `b` to the types of `a`,
+//     so conversions such as the ones of time, text and sql types apply, values failing to be
+//     converted are different,
+//   - `nil` and empty slices or maps are equivalent, `time.Time` values are equivalent
+//     when they are the same instant, NaN floats are equivalent.
+//
+// Options `Only` and `Omit` can be used to select fields to compare.
+func Diff(a, b any, options ...Option) ([]Difference, error) {
+	d, err := newDiffer(options)
+	if err != nil {
+		return nil, err
+	}
+	if err = d.diffRoot(a, b); err != nil {
+		return nil, err
+	}
+	return d.diffs, nil
+}
+
+// differ data structure for finding differences between values
+type differ struct {
+	ctx         *Context
+	diffs       []Difference
+	stopOnFirst bool
+	// visited pointers being compared, used for breaking circular references
+	visited map[visitedPointers]struct{}
+}
+
+// visitedPointers pair of pointers being compared
+type visitedPointers struct {
+	a, b         unsafe.Pointer
+	aType, bType reflect.Type
+}
+
+func newDiffer(options []Option) (*differ, error) {
+	ctx := defaultContext()
+	for _, opt := range options {
+		opt(ctx)
+	}
+	if err := ctx.prepare(); err != nil {
+		return nil, err
+	}
+	return &differ{ctx: ctx, visited: map[visitedPointers]struct{}{}}, nil
+}
+
+// diffRoot compares the root values, the values are copied to be addressable
+// so unexported struct fields can be accessed
+func (d *differ) diffRoot(a, b any) error {
+	if a == nil || b == nil {
+		if a != nil || b != nil {
+			d.addDiff("", a, b)
+		}
+		return nil
+	}
+	aVal, bVal := reflect.ValueOf(a), reflect.ValueOf(b)
+	return d.diff(d.ctx, "", diffAddressable(aVal), diffAddressable(bVal))
+}
+
+// addDiff records a difference
+func (d *differ) addDiff(path string, a, b any) {
+	d.diffs = append(d.diffs, Difference{Path: path, A: a, B: b})
+}
+
+// stopped checks if comparing should stop as a difference is found
+func (d *differ) stopped() bool {
+	return d.stopOnFirst && len(d.diffs) > 0
+}
+
+// diff compares values following the rules of buildCopier
+//
+//nolint:gocognit,gocyclo
+func (d *differ) diff(ctx *Context, path string, a, b reflect.Value) error {
+	if d.stopped() {
+		return nil
+	}
+	aType, bType := a.Type(), b.Type()
+	aKind, bKind := aType.Kind(), bType.Kind()
+
+	// Interfaces are compared by their inner values
+	if bKind == reflect.Interface {
+		if b.IsNil() {
+			if !a.IsZero() {
+				d.addDiff(path, a.Interface(), nil)
+			}
+			return nil
+		}
+		return d.diff(ctx, path, a, diffAddressable(b.Elem()))
+	}
+	if aKind == reflect.Interface {
+		if a.IsNil() {
+			if !diffIsNil(b) {
+				d.addDiff(path, nil, b.Interface())
+			}
+			return nil
+		}
+		return d.diff(ctx, path, diffAddressable(a.Elem()), b)
+	}
+
+	// Pointers are compared by their pointed values
+	if aKind == reflect.Pointer || bKind == reflect.Pointer {
+		if aKind != bKind && !ctx.CopyBetweenPtrAndValue {
+			return d.nonComparable(ctx, aType, bType)
+		}
+		return d.diffPointers(ctx, path, a, b)
+	}
+
+	// time.Time values are compared as instants
+	if aType == timeType && bType == timeType {
+		if !a.Interface().(time.Time).Equal(b.Interface().(time.Time)) { //nolint:forcetypeassert
+			d.addDiff(path, a.Interface(), b.Interface())
+		}
+		return nil
+	}
+
+	// Values of different types are compared after converting `b` to the type of `a` as copying does
+	if aType != bType && !diffStructural(aKind, bKind) {
+		return d.diffConverted(ctx, path, a, b)
+	}
+
+	switch {
+	case simpleKindMask&(1<<bKind) > 0 || bKind == reflect.Uintptr || bKind == reflect.UnsafePointer:
+		if !diffSimpleEqual(a, b) {
+			d.addDiff(path, a.Interface(), b.Interface())
+		}
+		return nil
+
+	case bKind == reflect.Chan:
+		if aType.Kind() != reflect.Chan || aType.Elem() != bType.Elem() ||
+			ctx.ChanCopyPolicy == ChanPolicyNonCopyable {
+			return d.nonComparable(ctx, aType, bType)
+		}
+		// Only shared channels can be compared
+		if ctx.ChanCopyPolicy == ChanPolicyShare && a.Pointer() != b.Pointer() {
+			d.addDiff(path, a.Interface(), b.Interface())
+		}
+		return nil
+
+	case bKind == reflect.Slice || bKind == reflect.Array:
+		if aKind != reflect.Slice && aKind != reflect.Array {
+			return d.nonComparable(ctx, aType, bType)
+		}
+		return d.diffSlices(ctx, path, a, b)
+
+	case bKind == reflect.Struct:
+		switch aKind { //nolint:exhaustive
+		case reflect.Struct:
+			// Sync primitives are reset when copying, nothing to compare
+			if bType.PkgPath() == "sync" {
+				return nil
+			}
+			return d.diffStructs(ctx, path, a, b)
+		case reflect.Map:
+			return d.diffStructToMap(ctx, path, a, b)
+		}
+
+	case bKind == reflect.Map:
+		switch aKind { //nolint:exhaustive
+		case reflect.Map:
+			return d.diffMaps(ctx, path, a, b)
+		case reflect.Struct:
+			return d.diffMapToStruct(ctx, path, a, b)
+		}
+	}
+	return d.nonComparable(ctx, aType, bType)
+}
+
+// nonComparable returns error when the values can't be compared unless non-copyable types are ignored
+func (d *differ) nonComparable(ctx *Context, aType, bType reflect.Type) error {
+	if ctx.IgnoreNonCopyableTypes {
+		return nil
+	}
+	return fmt.Errorf("%w: %v -> %v", ErrTypeNonCopyable, bType, aType)
+}
+
+// diffConverted compares `a` with the value of its type copied from `b`,
+// values of `b` failing to be converted such as unparsable strings are different
+func (d *differ) diffConverted(ctx *Context, path string, a, b reflect.Value) error {
+	cp, err := buildCopier(ctx, a.Type(), b.Type())
+	if err != nil {
+		if errors.Is(err, ErrTypeNonCopyable) {
+			return d.nonComparable(ctx, a.Type(), b.Type())
+		}
+		return err
+	}
+	if cp == defaultNopCopier {
+		return nil
+	}
+	converted := reflect.New(a.Type()).Elem()
+	if err = cp.Copy(converted, b); err != nil {
+		d.addDiff(path, a.Interface(), b.Interface())
+		return nil
+	}
+	return d.diff(ctx, path, a, converted)
+}
+
+// diffPointers compares pointers or a pointer with a value, nil pointers are equivalent to zero values
+func (d *differ) diffPointers(ctx *Context, path string, a, b reflect.Value) error {
+	aPtr, bPtr := a.Kind() == reflect.Pointer, b.Kind() == reflect.Pointer
+	switch {
+	case aPtr && bPtr:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				d.addDiff(path, a.Interface(), b.Interface())
+			}
+			return nil
+		}
+		key := visitedPointers{a: a.UnsafePointer(), b: b.UnsafePointer(), aType: a.Type(), bType: b.Type()}
+		if _, exists := d.visited[key]; exists {
+			return nil
+		}
+		d.visited[key] = struct{}{}
+		return d.diff(ctx, path, a.Elem(), b.Elem())
+	case bPtr:
+		if b.IsNil() {
+			if !a.IsZero() {
+				d.addDiff(path, a.Interface(), nil)
+			}
+			return nil
+		}
+		return d.diff(ctx, path, a, b.Elem())
+	default:
+		if a.IsNil() {
+			d.addDiff(path, nil, b.Interface())
+			return nil
+		}
+		return d.diff(ctx, path, a.Elem(), b)
+	}
+}
+
+// diffSlices compares slices and arrays item by item.
+// As copying to an array truncates the source or zeroes the remaining items, the same applies here.
+func (d *differ) diffSlices(ctx *Context, path string, a, b reflect.Value) error {
+	aLen, bLen := a.Len(), b.Len()
+	i := 0
+	for ; i < aLen && i < bLen; i++ {
+		if err := d.diff(ctx, diffIndexPath(path, i), a.Index(i), b.Index(i)); err != nil {
+			return err
+		}
+	}
+	for ; i < aLen; i++ {
+		if item := a.Index(i); a.Kind() == reflect.Slice || !item.IsZero() {
+			d.addDiff(diffIndexPath(path, i), item.Interface(), nil)
+		}
+	}
+	if a.Kind() == reflect.Slice {
+		for ; i < bLen; i++ {
+			d.addDiff(diffIndexPath(path, i), nil, b.Index(i).Interface())
+		}
+	}
+	return nil
+}
+
+// diffMaps compares maps entry by entry, keys of `b` are converted to the key type of `a`
+func (d *differ) diffMaps(ctx *Context, path string, a, b reflect.Value) error {
+	aKeyType, bKeyType := a.Type().Key(), b.Type().Key()
+	if aKeyType != bKeyType && !bKeyType.ConvertibleTo(aKeyType) {
+		return d.nonComparable(ctx, a.Type(), b.Type())
+	}
+	iter := b.MapRange()
+	for iter.Next() {
+		key := iter.Key()
+		aVal := a.MapIndex(key.Convert(aKeyType))
+		if !aVal.IsValid() {
+			d.addDiff(diffKeyPath(path, key), nil, iter.Value().Interface())
+			continue
+		}
+		err := d.diff(ctx, diffKeyPath(path, key), diffAddressable(aVal), diffAddressable(iter.Value()))
+		if err != nil {
+			return err
+		}
+	}
+	// Entries of `a` not in `b`
+	if !aKeyType.ConvertibleTo(bKeyType) {
+		return nil
+	}
+	iter = a.MapRange()
+	for iter.Next() {
+		if !b.MapIndex(iter.Key().Convert(bKeyType)).IsValid() {
+			d.addDiff(diffKeyPath(path, iter.Key()), iter.Value().Interface(), nil)
+		}
+	}
+	return nil
+}
+
+// diffStructs compares fields of structs matched by copy keys
+func (d *differ) diffStructs(ctx *Context, path string, a, b reflect.Value) error {
+	var aCopyingMethods map[string]*reflect.Method
+	if ctx.CopyViaCopyingMethod {
+		aCopyingMethods, _ = typeParseMethods(ctx, a.Type())
+	}
+	_, mapADirectFields, _, mapAInheritedFields := structParseAllFields(a.Type())
+	bDirectFields, mapBDirectFields, bInheritedFields, mapBInheritedFields := structParseAllFields(b.Type())
+	if ctx.hasFieldMasks() {
+		err := ctx.fieldMasksCheckKeys(a.Type(),
+			mapADirectFields, mapAInheritedFields, mapBDirectFields, mapBInheritedFields)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, key := range append(bDirectFields, bInheritedFields...) {
+		bDetail := mapBDirectFields[key]
+		if bDetail == nil {
+			bDetail = mapBInheritedFields[key]
+		}
+		if bDetail == nil || bDetail.ignored || bDetail.done {
+			continue
+		}
+
+		// Skip the field when it's excluded by field masks
+		fieldCtx := ctx
+		if ctx.hasFieldMasks() {
+			only, omit, included := ctx.fieldMasksInclude(key)
+			if !included {
+				continue
+			}
+			fieldCtx = ctx.withFieldMasks(only, omit)
+		}
+
+		// Values copied via copying methods can't be compared
+		if _, exists := aCopyingMethods["Copy"+strings.ToUpper(key[:1])+key[1:]]; exists {
+			bDetail.markDone()
+			continue
+		}
+
+		aDetail := mapADirectFields[key]
+		if aDetail == nil {
+			aDetail = mapAInheritedFields[key]
+		}
+		if aDetail == nil || aDetail.ignored || aDetail.done {
+			continue
+		}
+
+		// Fields of nil embedded struct pointers are zero
+		fieldCtx = fieldCtx.withTimeFormat(aDetail.timeFormat.merge(bDetail.timeFormat))
+		aField, bField := diffStructField(a, aDetail), diffStructField(b, bDetail)
+		err := d.diff(fieldCtx, diffFieldPath(path, aDetail.field.Name), aField, bField)
+		if err != nil {
+			// NOTE: Unexported fields not copyable are not copied, so ignore them as well
+			if !aDetail.required && !bDetail.required && !aDetail.field.IsExported() &&
+				errors.Is(err, ErrTypeNonCopyable) {
+				continue
+			}
+			return err
+		}
+		aDetail.markDone()
+		bDetail.markDone()
+	}
+	return nil
+}
+
+// diffStructToMap compares fields of struct `b` with entries of map `a` having the same keys
+func (d *differ) diffStructToMap(ctx *Context, path string, a, b reflect.Value) error {
+	aKeyType := a.Type().Key()
+	if !strType.ConvertibleTo(aKeyType) {
+		return d.nonComparable(ctx, a.Type(), b.Type())
+	}
+	bDirectFields, mapBDirectFields, bInheritedFields, mapBInheritedFields := structParseAllFields(b.Type())
+	for _, key := range append(bDirectFields, bInheritedFields...) {
+		bDetail := mapBDirectFields[key]
+		if bDetail == nil {
+			bDetail = mapBInheritedFields[key]
+		}
+		if bDetail == nil || bDetail.ignored || bDetail.done || bDetail.field.Anonymous {
+			continue
+		}
+		fieldCtx := ctx
+		if ctx.hasFieldMasks() {
+			only, omit, included := ctx.fieldMasksInclude(key)
+			if !included {
+				continue
+			}
+			fieldCtx = ctx.withFieldMasks(only, omit)
+		}
+		bDetail.markDone()
+
+		fieldCtx = fieldCtx.withTimeFormat(bDetail.timeFormat)
+		bField := diffStructField(b, bDetail)
+		aVal := a.MapIndex(reflect.ValueOf(key).Convert(aKeyType))
+		if !aVal.IsValid() {
+			d.addDiff(diffKeyPath(path, reflect.ValueOf(key)), nil, bField.Interface())
+			continue
+		}
+		if err := d.diff(fieldCtx, diffKeyPath(path, reflect.ValueOf(key)), diffAddressable(aVal), bField); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffMapToStruct compares fields of struct `a` with entries of map `b` having the same keys
+func (d *differ) diffMapToStruct(ctx *Context, path string, a, b reflect.Value) error {
+	bKeyType := b.Type().Key()
+	if !strType.ConvertibleTo(bKeyType) {
+		return d.nonComparable(ctx, a.Type(), b.Type())
+	}
+	aDirectFields, mapADirectFields, aInheritedFields, mapAInheritedFields := structParseAllFields(a.Type())
+	for _, key := range append(aDirectFields, aInheritedFields...) {
+		aDetail := mapADirectFields[key]
+		if aDetail == nil {
+			aDetail = mapAInheritedFields[key]
+		}
+		if aDetail == nil || aDetail.ignored || aDetail.done || aDetail.field.Anonymous {
+			continue
+		}
+		fieldCtx := ctx
+		if ctx.hasFieldMasks() {
+			only, omit, included := ctx.fieldMasksInclude(key)
+			if !included {
+				continue
+			}
+			fieldCtx = ctx.withFieldMasks(only, omit)
+		}
+		aDetail.markDone()
+
+		fieldCtx = fieldCtx.withTimeFormat(aDetail.timeFormat)
+		bVal := b.MapIndex(reflect.ValueOf(key).Convert(bKeyType))
+		if !bVal.IsValid() {
+			continue
+		}
+		err := d.diff(fieldCtx, diffFieldPath(path, aDetail.field.Name), diffStructField(a, aDetail),
+			diffAddressable(bVal))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffStructField gets the nested struct field, returns zero value if an embedded struct pointer is nil
+func diffStructField(structVal reflect.Value, detail *fieldDetail) reflect.Value {
+	field := structVal
+	for _, idx := range detail.index {
+		if field.Kind() == reflect.Pointer {
+			if field.IsNil() {
+				return reflect.New(detail.field.Type).Elem()
+			}
+			field = field.Elem()
+		}
+		field = diffAddressable(field.Field(idx))
+	}
+	return field
+}
+
+// diffAddressable returns an addressable value which can be accessed even if it's of an unexported field
+func diffAddressable(val reflect.Value) reflect.Value {
+	if val.CanAddr() {
+		if !val.CanInterface() {
+			val = reflect.NewAt(val.Type(), unsafe.Pointer(val.UnsafeAddr())).Elem() //nolint:gosec
+		}
+		return val
+	}
+	newVal := reflect.New(val.Type()).Elem()
+	newVal.Set(val)
+	return newVal
+}
+
+// diffIsNil checks if the value is a nil pointer, interface, slice, map, func or channel
+func diffIsNil(val reflect.Value) bool {
+	switch val.Kind() { //nolint:exhaustive
+	case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map, reflect.Func, reflect.Chan:
+		return val.IsNil()
+	default:
+		return false
+	}
+}
+
+// diffStructural checks if values of the kinds are compared by their items, fields or entries
+func diffStructural(aKind, bKind reflect.Kind) bool {
+	switch bKind { //nolint:exhaustive
+	case reflect.Slice, reflect.Array:
+		return aKind == reflect.Slice || aKind == reflect.Array
+	case reflect.Struct, reflect.Map:
+		return aKind == reflect.Struct || aKind == reflect.Map
+	case reflect.Chan:
+		return aKind == reflect.Chan
+	default:
+		return false
+	}
+}
+
+// diffSimpleEqual checks if values of the same simple type are equal, NaN values are equal
+func diffSimpleEqual(a, b reflect.Value) bool {
+	switch a.Kind() { //nolint:exhaustive
+	case reflect.Func, reflect.UnsafePointer:
+		return a.Pointer() == b.Pointer()
+	case reflect.Float32, reflect.Float64:
+		return diffFloatEqual(a.Float(), b.Float())
+	case reflect.Complex64, reflect.Complex128:
+		ac, bc := a.Complex(), b.Complex()
+		return diffFloatEqual(real(ac), real(bc)) && diffFloatEqual(imag(ac), imag(bc))
+	default:
+		return a.Interface() == b.Interface()
+	}
+}
+
+// diffFloatEqual checks if the floats are equal, NaN values are equal
+func diffFloatEqual(a, b float64) bool {
+	return a == b || a != a && b != b //nolint:gocritic
+}
+
+// diffFieldPath returns path of the struct field
+func diffFieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// diffIndexPath returns path of the slice or array item
+func diffIndexPath(path string, index int) string {
+	return path + "[" + strconv.Itoa(index) + "]"
+}
+
+// diffKeyPath returns path of the map entry
+func diffKeyPath(path string, key reflect.Value) string {
+	return fmt.Sprintf("%s[%v]", path, key.Interface())
+}
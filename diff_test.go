@@ -0,0 +1,298 @@
+package deepcopy
+
+import (
+	"database/sql"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testDiffBase struct {
+	ID      int
+	Version int `copy:"-"`
+}
+
+type testDiffNode struct {
+	Value int
+	Next  *testDiffNode
+}
+
+func Test_Diff(t *testing.T) {
+	t.Run("#1: equal values of simple types", func(t *testing.T) {
+		diffs, err := Diff(1, 1)
+		assert.Nil(t, err)
+		assert.Nil(t, diffs)
+
+		diffs, err = Diff(int64(1), IntT(1))
+		assert.Nil(t, err)
+		assert.Nil(t, diffs)
+
+		diffs, err = Diff("a", "b")
+		assert.Nil(t, err)
+		assert.Equal(t, []Difference{{Path: "", A: "a", B: "b"}}, diffs)
+	})
+
+	t.Run("#2: structs with different fields", func(t *testing.T) {
+		type SS struct {
+			testDiffBase
+			Name   string
+			Age    int32
+			Tags   []string
+			Secret string `copy:"-"`
+			Extra  int
+		}
+		type DD struct {
+			testDiffBase
+			FullName string `copy:"Name"`
+			Age      int64
+			Tags     []string
+			Secret   string
+			age      int
+		}
+
+		s := SS{testDiffBase: testDiffBase{ID: 1, Version: 2}, Name: "a", Age: 10, Tags: []string{"x", "y"},
+			Secret: "s", Extra: 3}
+		d := DD{testDiffBase: testDiffBase{ID: 1}, FullName: "a", Age: 10, Tags: []string{"x", "y"}, age: 5}
+		diffs, err := Diff(d, s)
+		assert.Nil(t, err)
+		assert.Nil(t, diffs)
+
+		d.ID, d.FullName, d.Tags = 2, "b", []string{"x", "z", "w"}
+		diffs, err = Diff(d, &s)
+		assert.Nil(t, err)
+		assert.Equal(t, []Difference{
+			{Path: "testDiffBase.ID", A: 2, B: 1},
+			{Path: "FullName", A: "b", B: "a"},
+			{Path: "Tags[1]", A: "z", B: "y"},
+			{Path: "Tags[2]", A: "w", B: nil},
+		}, diffs)
+	})
+
+	t.Run("#3: inherited fields and unexported fields", func(t *testing.T) {
+		type SS struct {
+			*testDiffBase
+			name string
+		}
+		type DD struct {
+			ID   int
+			name string
+		}
+
+		diffs, err := Diff(DD{ID: 1, name: "a"}, SS{testDiffBase: &testDiffBase{ID: 1}, name: "a"})
+		assert.Nil(t, err)
+		assert.Nil(t, diffs)
+
+		diffs, err = Diff(DD{ID: 1, name: "a"}, SS{name: "b"})
+		assert.Nil(t, err)
+		assert.Equal(t, []Difference{
+			{Path: "name", A: "a", B: "b"},
+			{Path: "ID", A: 1, B: 0},
+		}, diffs)
+	})
+
+	t.Run("#4: pointers and values", func(t *testing.T) {
+		v := 1
+		diffs, err := Diff(&v, 1)
+		assert.Nil(t, err)
+		assert.Nil(t, diffs)
+
+		diffs, err = Diff(0, (*int)(nil))
+		assert.Nil(t, err)
+		assert.Nil(t, diffs)
+
+		diffs, err = Diff((*int)(nil), &v)
+		assert.Nil(t, err)
+		assert.Equal(t, []Difference{{Path: "", A: (*int)(nil), B: &v}}, diffs)
+
+		_, err = Diff(&v, 1, CopyBetweenPtrAndValue(false))
+		assert.ErrorIs(t, err, ErrTypeNonCopyable)
+	})
+
+	t.Run("#5: slices and arrays", func(t *testing.T) {
+		diffs, err := Diff([]int{}, []int(nil))
+		assert.Nil(t, err)
+		assert.Nil(t, diffs)
+
+		diffs, err = Diff([3]int{1, 2}, []int{1, 2})
+		assert.Nil(t, err)
+		assert.Nil(t, diffs)
+
+		diffs, err = Diff([]int{1, 2}, []int{1, 3, 4})
+		assert.Nil(t, err)
+		assert.Equal(t, []Difference{
+			{Path: "[1]", A: 2, B: 3},
+			{Path: "[2]", A: nil, B: 4},
+		}, diffs)
+	})
+
+	t.Run("#6: maps", func(t *testing.T) {
+		diffs, err := Diff(map[string]int{}, map[string]int(nil))
+		assert.Nil(t, err)
+		assert.Nil(t, diffs)
+
+		diffs, err = Diff(map[string]int64{"a": 1, "b": 2}, map[StrT]int{"a": 1, "c": 3})
+		assert.Nil(t, err)
+		assert.Equal(t, []Difference{
+			{Path: "[c]", A: nil, B: 3},
+			{Path: "[b]", A: int64(2), B: nil},
+		}, diffs)
+	})
+
+	t.Run("#7: structs and maps", func(t *testing.T) {
+		type SS struct {
+			A int
+			B string `copy:"b"`
+		}
+		diffs, err := Diff(map[string]any{"A": 1, "b": "x"}, SS{A: 1, B: "x"})
+		assert.Nil(t, err)
+		assert.Nil(t, diffs)
+
+		diffs, err = Diff(map[string]any{"A": 2}, SS{A: 1, B: "x"})
+		assert.Nil(t, err)
+		assert.Equal(t, []Difference{
+			{Path: "[A]", A: 2, B: 1},
+			{Path: "[b]", A: nil, B: "x"},
+		}, diffs)
+
+		diffs, err = Diff(SS{A: 1, B: "y"}, map[string]any{"b": "x"})
+		assert.Nil(t, err)
+		assert.Equal(t, []Difference{{Path: "B", A: "y", B: "x"}}, diffs)
+	})
+
+	t.Run("#8: interfaces and time values", func(t *testing.T) {
+		now := time.Now()
+		diffs, err := Diff([]any{1, nil, now}, []any{1, nil, now.UTC()})
+		assert.Nil(t, err)
+		assert.Nil(t, diffs)
+
+		diffs, err = Diff([]any{1, nil}, []any{nil, 2})
+		assert.Nil(t, err)
+		assert.Equal(t, []Difference{
+			{Path: "[0]", A: 1, B: nil},
+			{Path: "[1]", A: nil, B: 2},
+		}, diffs)
+	})
+
+	t.Run("#9: circular references", func(t *testing.T) {
+		a := &testDiffNode{Value: 1}
+		a.Next = a
+		b := &testDiffNode{Value: 1}
+		b.Next = &testDiffNode{Value: 1, Next: b}
+		diffs, err := Diff(a, b)
+		assert.Nil(t, err)
+		assert.Nil(t, diffs)
+
+		b.Next.Value = 2
+		diffs, err = Diff(a, b)
+		assert.Nil(t, err)
+		assert.Equal(t, []Difference{{Path: "Next.Value", A: 1, B: 2}}, diffs)
+	})
+
+	t.Run("#10: with options", func(t *testing.T) {
+		type SS struct {
+			A int
+			B int
+			C chan int
+		}
+		_, err := Diff(SS{C: make(chan int)}, SS{C: make(chan int)})
+		assert.ErrorIs(t, err, ErrTypeNonCopyable)
+
+		diffs, err := Diff(SS{A: 1, B: 2}, SS{A: 1, B: 3}, IgnoreNonCopyableTypes(true))
+		assert.Nil(t, err)
+		assert.Equal(t, []Difference{{Path: "B", A: 2, B: 3}}, diffs)
+
+		diffs, err = Diff(SS{A: 1, B: 2}, SS{A: 1, B: 3}, Omit("B", "C"))
+		assert.Nil(t, err)
+		assert.Nil(t, diffs)
+
+		_, err = Diff(SS{}, SS{}, Only("X"))
+		assert.ErrorIs(t, err, ErrFieldMaskInvalid)
+	})
+
+	t.Run("#11: nil values", func(t *testing.T) {
+		diffs, err := Diff(nil, nil)
+		assert.Nil(t, err)
+		assert.Nil(t, diffs)
+
+		diffs, err = Diff(nil, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, []Difference{{Path: "", A: nil, B: 1}}, diffs)
+	})
+
+	t.Run("#12: values converted by copying", func(t *testing.T) {
+		type SS struct {
+			D time.Duration
+			T time.Time `copy:",layout=DateOnly"`
+			N sql.NullInt64
+			C testColor
+			F float64
+		}
+		type DD struct {
+			D string
+			T string
+			N int64
+			C string
+			F float32
+		}
+		s := SS{D: time.Second, T: time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC),
+			N: sql.NullInt64{Int64: 1, Valid: true}, C: 1, F: math.NaN()}
+		opts := []Option{TextConversion(TextConvAll), TimeConversion(true)}
+		var d DD
+		err := Copy(&d, s, opts...)
+		assert.Nil(t, err)
+		diffs, err := Diff(d, s, opts...)
+		assert.Nil(t, err)
+		assert.Nil(t, diffs)
+
+		var s2 SS
+		err = Copy(&s2, d, opts...)
+		assert.Nil(t, err)
+		diffs, err = Diff(s2, d, opts...)
+		assert.Nil(t, err)
+		assert.Nil(t, diffs)
+
+		d.D, d.C = "2s", "green"
+		diffs, err = Diff(d, s, opts...)
+		assert.Nil(t, err)
+		assert.Equal(t, []Difference{{Path: "D", A: "2s", B: "1s"}, {Path: "C", A: "green", B: "red"}}, diffs)
+
+		// Values failing to be converted are different
+		diffs, err = Diff(s, DD{T: "2024-05-06", D: "1 hour", N: 1, C: "red", F: float32(math.NaN())}, opts...)
+		assert.Nil(t, err)
+		assert.Equal(t, []Difference{{Path: "D", A: time.Second, B: "1 hour"}}, diffs)
+
+		equal, err := Equal([]complex128{complex(math.NaN(), 1)}, []complex64{complex(float32(math.NaN()), 1)})
+		assert.Nil(t, err)
+		assert.True(t, equal)
+	})
+}
+
+func Test_Equal(t *testing.T) {
+	t.Run("#1: equal values", func(t *testing.T) {
+		type SS struct {
+			A int
+			B []string
+		}
+		type DD struct {
+			A int64
+			B []string
+		}
+		equal, err := Equal(DD{A: 1, B: []string{"x"}}, &SS{A: 1, B: []string{"x"}})
+		assert.Nil(t, err)
+		assert.True(t, equal)
+	})
+
+	t.Run("#2: different values", func(t *testing.T) {
+		equal, err := Equal([]int{1, 2, 3}, []int{2, 3, 4})
+		assert.Nil(t, err)
+		assert.False(t, equal)
+	})
+
+	t.Run("#3: non-comparable values", func(t *testing.T) {
+		equal, err := Equal(1, []int{1})
+		assert.ErrorIs(t, err, ErrTypeNonCopyable)
+		assert.False(t, equal)
+	})
+}
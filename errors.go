@@ -20,4 +20,10 @@ var (
 	ErrFieldRequireCopying = errors.New("ErrFieldRequireCopying")
 	// ErrMethodInvalid returned when copying method of a struct is not valid
 	ErrMethodInvalid = errors.New("ErrMethodInvalid")
+	// ErrDepthExceeded returned when values are nested deeper than the configured max depth
+	ErrDepthExceeded = errors.New("ErrDepthExceeded")
+	// ErrFieldMaskInvalid returned when a field path given to `Only` or `Omit` is malformed or unknown
+	ErrFieldMaskInvalid = errors.New("ErrFieldMaskInvalid")
+	// ErrFieldUnmatched returned when struct fields have no corresponding fields in strict matching
+	ErrFieldUnmatched = errors.New("ErrFieldUnmatched")
 )
@@ -0,0 +1,166 @@
+package deepcopy
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// fieldMask compiled tree of field paths used to include or exclude fields when copying.
+// A node without child fields matches the whole field.
+type fieldMask struct {
+	path   string
+	fields map[string]*fieldMask
+}
+
+var (
+	// internedFieldMasks global cache of compiled field masks by their paths
+	internedFieldMasks internMap[string, *fieldMask]
+)
+
+// compileFieldMask compiles the given field paths into a field mask tree.
+// Compiled masks are cached, so equivalent paths such as `A,B`, `B,A,B` or `A,A.C,B` always result
+// in the same mask, and so in the same cached copiers.
+// NOTE: the cache is bounded as paths can come from untrusted input, e.g. `?fields=` of requests.
+func compileFieldMask(paths []string) (*fieldMask, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	sortedPaths := make([]string, len(paths))
+	copy(sortedPaths, paths)
+	sort.Strings(sortedPaths)
+	maskStr := strings.Join(sortedPaths, ",")
+
+	if mask, found := internedFieldMasks.get(maskStr); found {
+		return mask, nil
+	}
+
+	mask := &fieldMask{fields: map[string]*fieldMask{}}
+	// NOTE: as paths are sorted, a path always comes before the ones it prefixes
+	for _, path := range sortedPaths {
+		node := mask
+		names := strings.Split(path, ".")
+		for i, name := range names {
+			if name == "" {
+				return nil, fmt.Errorf("%w: field path '%s' is malformed", ErrFieldMaskInvalid, path)
+			}
+			child := node.fields[name]
+			if child == nil {
+				child = &fieldMask{path: strings.Join(names[:i+1], ".")}
+				if node.fields == nil {
+					node.fields = map[string]*fieldMask{}
+				}
+				node.fields[name] = child
+			} else if len(child.fields) == 0 {
+				break // The whole field is already matched
+			}
+			node = child
+		}
+	}
+
+	// Equivalent paths are interned by the paths of the mask, so they share the same mask
+	normalizedStr := strings.Join(mask.leafPaths(nil), ",")
+	if normalizedStr != maskStr {
+		if normalizedMask, found := internedFieldMasks.get(normalizedStr); found {
+			mask = normalizedMask
+		} else {
+			mask = internedFieldMasks.add(normalizedStr, mask)
+		}
+	}
+	return internedFieldMasks.add(maskStr, mask), nil
+}
+
+// leafPaths appends paths of the mask nodes matching whole fields in sorted order
+func (mask *fieldMask) leafPaths(paths []string) []string {
+	if len(mask.fields) == 0 {
+		return append(paths, mask.path)
+	}
+	names := make([]string, 0, len(mask.fields))
+	for name := range mask.fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		paths = mask.fields[name].leafPaths(paths)
+	}
+	return paths
+}
+
+// hasFieldMasks checks if any field mask applies at the current level
+func (ctx *Context) hasFieldMasks() bool {
+	return ctx.onlyMask != nil || ctx.omitMask != nil
+}
+
+// fieldMasksInclude checks whether the field with the given key is included by the field masks
+// and returns the masks applying to the field value
+func (ctx *Context) fieldMasksInclude(key string) (only, omit *fieldMask, included bool) {
+	if ctx.onlyMask != nil {
+		only = ctx.onlyMask.fields[key]
+		if only == nil {
+			return nil, nil, false
+		}
+		if len(only.fields) == 0 {
+			only = nil // The whole field is included
+		}
+	}
+	if ctx.omitMask != nil {
+		omit = ctx.omitMask.fields[key]
+		if omit != nil && len(omit.fields) == 0 {
+			return nil, nil, false // The whole field is excluded
+		}
+	}
+	return only, omit, true
+}
+
+// withFieldMasks returns a context with the given field masks applied
+func (ctx *Context) withFieldMasks(only, omit *fieldMask) *Context {
+	if ctx.onlyMask == only && ctx.omitMask == omit {
+		return ctx
+	}
+	options := *ctx.copierOptions
+	options.onlyMask = only
+	options.omitMask = omit
+	return ctx.withOptions(options)
+}
+
+// fieldMasksCheckKeys checks all fields listed in the field masks exist in the given key sets
+func (ctx *Context) fieldMasksCheckKeys(typ reflect.Type, keySets ...map[string]*fieldDetail) error {
+	var unknownPaths []string
+	for _, mask := range []*fieldMask{ctx.onlyMask, ctx.omitMask} {
+		if mask == nil {
+			continue
+		}
+	NextKey:
+		for key, m := range mask.fields {
+			for _, keySet := range keySets {
+				if _, found := keySet[key]; found {
+					continue NextKey
+				}
+			}
+			unknownPaths = append(unknownPaths, m.path)
+		}
+	}
+	if len(unknownPaths) > 0 {
+		sort.Strings(unknownPaths)
+		return fmt.Errorf("%w: field path '%s' is unknown to type '%v'",
+			ErrFieldMaskInvalid, strings.Join(unknownPaths, ","), typ)
+	}
+	return nil
+}
+
+// fieldMasksErrNotApplicable returns error when field masks reach types having no fields
+func (ctx *Context) fieldMasksErrNotApplicable(dstType, srcType reflect.Type) error {
+	var paths []string
+	for _, mask := range []*fieldMask{ctx.onlyMask, ctx.omitMask} {
+		if mask == nil {
+			continue
+		}
+		for _, m := range mask.fields {
+			paths = append(paths, m.path)
+		}
+	}
+	sort.Strings(paths)
+	return fmt.Errorf("%w: field path '%s' is not applicable to copying %v -> %v",
+		ErrFieldMaskInvalid, strings.Join(paths, ","), srcType, dstType)
+}
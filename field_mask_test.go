@@ -0,0 +1,237 @@
+package deepcopy
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type maskAddress struct {
+	City   string
+	Street string
+}
+
+type maskItem struct {
+	Name  string
+	Price int
+}
+
+type maskSrc struct {
+	ID      int `copy:"id"`
+	Name    string
+	Address *maskAddress
+	Items   []maskItem
+	ItemMap map[string]maskItem
+}
+
+type maskDst struct {
+	ID      int `copy:"id"`
+	Name    string
+	Address maskAddress
+	Items   []*maskItem
+	ItemMap map[string]maskItem
+}
+
+var maskSrcValue = maskSrc{
+	ID:      1,
+	Name:    "name",
+	Address: &maskAddress{City: "city", Street: "street"},
+	Items:   []maskItem{{Name: "a", Price: 1}, {Name: "b", Price: 2}},
+	ItemMap: map[string]maskItem{"x": {Name: "x", Price: 3}},
+}
+
+func Test_compileFieldMask(t *testing.T) {
+	t.Run("#1: no paths", func(t *testing.T) {
+		mask, err := compileFieldMask(nil)
+		assert.Nil(t, err)
+		assert.Nil(t, mask)
+	})
+
+	t.Run("#2: compiled masks are cached", func(t *testing.T) {
+		mask1, err := compileFieldMask([]string{"A.B", "C"})
+		assert.Nil(t, err)
+		mask2, err := compileFieldMask([]string{"C", "A.B"})
+		assert.Nil(t, err)
+		assert.True(t, mask1 == mask2)
+		assert.Equal(t, "A.B", mask1.fields["A"].fields["B"].path)
+		assert.Equal(t, 0, len(mask1.fields["C"].fields))
+	})
+
+	t.Run("#3: whole field path wins", func(t *testing.T) {
+		mask, err := compileFieldMask([]string{"A.B", "A", "A.C.D"})
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(mask.fields["A"].fields))
+	})
+
+	t.Run("#4: malformed path", func(t *testing.T) {
+		_, err := compileFieldMask([]string{"A..B"})
+		assert.ErrorIs(t, err, ErrFieldMaskInvalid)
+		_, err = compileFieldMask([]string{""})
+		assert.ErrorIs(t, err, ErrFieldMaskInvalid)
+	})
+
+	t.Run("#5: number of cached masks is bounded", func(t *testing.T) {
+		for i := 0; i <= maxInternedValues; i++ {
+			_, err := compileFieldMask([]string{"F" + strconv.Itoa(i)})
+			assert.Nil(t, err)
+		}
+		assert.LessOrEqual(t, internedFieldMasks.len(), maxInternedValues)
+
+		mask1, err := compileFieldMask([]string{"A"})
+		assert.Nil(t, err)
+		mask2, err := compileFieldMask([]string{"A"})
+		assert.Nil(t, err)
+		assert.True(t, mask1 == mask2)
+	})
+
+	t.Run("#6: equivalent paths share the same mask", func(t *testing.T) {
+		mask1, err := compileFieldMask([]string{"A", "B.C"})
+		assert.Nil(t, err)
+		for _, paths := range [][]string{{"B.C", "A"}, {"A", "B.C", "A"}, {"A.X", "A", "B.C", "B.C"}} {
+			mask2, err := compileFieldMask(paths)
+			assert.Nil(t, err)
+			assert.True(t, mask1 == mask2, paths)
+		}
+	})
+}
+
+func Test_Copy_fieldMask(t *testing.T) {
+	t.Run("#1: only top-level fields", func(t *testing.T) {
+		var d maskDst
+		err := Copy(&d, maskSrcValue, Only("id", "Name"))
+		assert.Nil(t, err)
+		assert.Equal(t, maskDst{ID: 1, Name: "name"}, d)
+	})
+
+	t.Run("#2: only nested fields", func(t *testing.T) {
+		var d maskDst
+		err := Copy(&d, maskSrcValue, Only("Address.City", "Items.Price", "ItemMap.Name"))
+		assert.Nil(t, err)
+		assert.Equal(t, maskDst{
+			Address: maskAddress{City: "city"},
+			Items:   []*maskItem{{Price: 1}, {Price: 2}},
+			ItemMap: map[string]maskItem{"x": {Name: "x"}},
+		}, d)
+	})
+
+	t.Run("#3: omit fields", func(t *testing.T) {
+		var d maskDst
+		err := Copy(&d, maskSrcValue, Omit("Name", "Address.Street", "Items.Name", "ItemMap"))
+		assert.Nil(t, err)
+		assert.Equal(t, maskDst{
+			ID:      1,
+			Address: maskAddress{City: "city"},
+			Items:   []*maskItem{{Price: 1}, {Price: 2}},
+		}, d)
+	})
+
+	t.Run("#4: only and omit together", func(t *testing.T) {
+		var d maskDst
+		err := Copy(&d, maskSrcValue, Only("Address", "Items"), Omit("Address.Street"))
+		assert.Nil(t, err)
+		assert.Equal(t, maskDst{
+			Address: maskAddress{City: "city"},
+			Items:   []*maskItem{{Name: "a", Price: 1}, {Name: "b", Price: 2}},
+		}, d)
+	})
+
+	t.Run("#5: masked required fields are not checked", func(t *testing.T) {
+		type SS struct {
+			I int
+			S string
+		}
+		type DD struct {
+			I int
+			S string `copy:",required"`
+		}
+		var d DD
+		err := Copy(&d, SS{I: 1, S: "s"}, Omit("S"))
+		assert.Nil(t, err)
+		assert.Equal(t, DD{I: 1}, d)
+	})
+
+	t.Run("#6: through interfaces", func(t *testing.T) {
+		var d any
+		err := Copy(&d, []any{maskItem{Name: "a", Price: 1}}, Only("Price"))
+		assert.Nil(t, err)
+		assert.Equal(t, []any{maskItem{Price: 1}}, d)
+	})
+
+	t.Run("#7: struct to map", func(t *testing.T) {
+		var d map[string]any
+		err := Copy(&d, maskSrcValue, Only("id", "Address.City"))
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]any{"id": 1, "Address": &maskAddress{City: "city"}}, d)
+	})
+
+	t.Run("#8: map to struct", func(t *testing.T) {
+		var d maskDst
+		s := map[string]any{"id": 1, "Name": "name", "Address": map[string]any{"City": "city", "Street": "street"}}
+		err := Copy(&d, s, Omit("Name", "Address.City"))
+		assert.Nil(t, err)
+		assert.Equal(t, maskDst{ID: 1, Address: maskAddress{Street: "street"}}, d)
+	})
+
+	t.Run("#9: equivalent masks share the same cached copier", func(t *testing.T) {
+		type SS struct {
+			A int
+			B string
+			C int
+		}
+		type DD struct {
+			A int
+			B string
+			C int
+		}
+		ClearCache()
+		for _, paths := range [][]string{{"A", "B"}, {"B", "A"}, {"A", "B", "B"}} {
+			var d DD
+			err := Copy(&d, SS{A: 1, B: "b", C: 2}, Only(paths...))
+			assert.Nil(t, err)
+			assert.Equal(t, DD{A: 1, B: "b"}, d)
+		}
+		assert.Equal(t, 1, CacheStats().Entries)
+	})
+}
+
+func Test_Copy_fieldMask_error(t *testing.T) {
+	t.Run("#1: unknown field", func(t *testing.T) {
+		var d maskDst
+		err := Copy(&d, maskSrcValue, Only("id", "Unknown"))
+		assert.ErrorIs(t, err, ErrFieldMaskInvalid)
+		assert.ErrorContains(t, err, "'Unknown'")
+	})
+
+	t.Run("#2: unknown nested field", func(t *testing.T) {
+		var d maskDst
+		err := Copy(&d, maskSrcValue, Omit("Items.Cost"))
+		assert.ErrorIs(t, err, ErrFieldMaskInvalid)
+		assert.ErrorContains(t, err, "'Items.Cost'")
+	})
+
+	t.Run("#3: path goes into a field having no sub-fields", func(t *testing.T) {
+		var d maskDst
+		err := Copy(&d, maskSrcValue, Only("Name.X"))
+		assert.ErrorIs(t, err, ErrFieldMaskInvalid)
+		assert.ErrorContains(t, err, "'Name.X'")
+	})
+
+	t.Run("#4: malformed path", func(t *testing.T) {
+		var d maskDst
+		err := Copy(&d, maskSrcValue, Only("Address."))
+		assert.ErrorIs(t, err, ErrFieldMaskInvalid)
+	})
+
+	t.Run("#5: struct to map with unknown field", func(t *testing.T) {
+		var d map[string]any
+		err := Copy(&d, maskSrcValue, Only("Unknown"))
+		assert.ErrorIs(t, err, ErrFieldMaskInvalid)
+	})
+
+	t.Run("#6: map to struct with unknown field", func(t *testing.T) {
+		var d maskDst
+		err := Copy(&d, map[string]any{"id": 1}, Omit("Unknown"))
+		assert.ErrorIs(t, err, ErrFieldMaskInvalid)
+	})
+}
@@ -0,0 +1,155 @@
+// Package gentest provides helpers for testing copy functions generated by `cmd/deepcopy-gen`.
+package gentest
+
+import (
+	"fmt"
+	"go/build"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/tiendc/go-deepcopy"
+)
+
+const (
+	// numSourceValues number of source values to verify copy functions with
+	numSourceValues = 20
+	// maxFillDepth max nesting level of filled values, deeper values are left zero
+	maxFillDepth = 6
+)
+
+// VerifyCopyFunc checks the copy function gives the same results as the reflective copying
+// of `deepcopy.Copy` for source values filled with generated data.
+func VerifyCopyFunc[D, S any](t testing.TB, fn func(dst *D, src *S) error) {
+	t.Helper()
+	for seed := 0; seed < numSourceValues; seed++ {
+		var src S
+		f := &filler{seed: seed}
+		f.fill(reflect.ValueOf(&src).Elem(), 0)
+
+		var genDst, reflectDst D
+		genErr := fn(&genDst, &src)
+		reflectErr := deepcopy.Copy(&reflectDst, &src, deepcopy.UseRegisteredCopyFuncs(false))
+		if (genErr == nil) != (reflectErr == nil) {
+			t.Errorf("seed %d: errors differ: generated copy returns '%v', reflective copy returns '%v'",
+				seed, genErr, reflectErr)
+			continue
+		}
+		if genErr == nil && !reflect.DeepEqual(genDst, reflectDst) {
+			t.Errorf("seed %d: results differ:\ngenerated copy:  %+v\nreflective copy: %+v",
+				seed, genDst, reflectDst)
+		}
+	}
+}
+
+var (
+	// standardPackages cache of checking standard packages
+	standardPackages sync.Map
+)
+
+// isStandardPackage checks if the package is a Go standard package
+func isStandardPackage(pkgPath string) bool {
+	if pkgPath == "" {
+		return false
+	}
+	if std, ok := standardPackages.Load(pkgPath); ok {
+		return std.(bool) //nolint:forcetypeassert
+	}
+	pkg, err := build.Import(pkgPath, "", build.FindOnly)
+	std := err == nil && pkg.Goroot
+	standardPackages.Store(pkgPath, std)
+	return std
+}
+
+// filler fills values with deterministic data generated from a seed
+type filler struct {
+	seed int
+	n    int
+}
+
+// next returns the next generated number
+func (f *filler) next() int {
+	f.n++
+	return f.seed*31 + f.n
+}
+
+// fill fills the value with generated data
+//
+//nolint:gocyclo
+func (f *filler) fill(v reflect.Value, depth int) {
+	if !v.CanSet() {
+		v = reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem() //nolint:gosec
+	}
+	n := f.next()
+
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.Bool:
+		v.SetBool(n%2 == 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(n))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(uint64(n)) //nolint:gosec
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(float64(n) / 2) //nolint:mnd
+	case reflect.Complex64, reflect.Complex128:
+		v.SetComplex(complex(float64(n), 1))
+	case reflect.String:
+		v.SetString(fmt.Sprintf("s%d", n))
+	case reflect.Interface:
+		// Only empty interfaces are filled as values of other interfaces are unknown
+		if v.NumMethod() == 0 && n%3 != 0 {
+			v.Set(reflect.ValueOf(fmt.Sprintf("s%d", n)))
+		}
+	case reflect.Pointer:
+		if depth < maxFillDepth && n%5 != 0 {
+			ptr := reflect.New(v.Type().Elem())
+			f.fill(ptr.Elem(), depth+1)
+			v.Set(ptr)
+		}
+	case reflect.Slice:
+		if depth < maxFillDepth && n%4 != 0 {
+			length := n % 3 //nolint:mnd
+			slice := reflect.MakeSlice(v.Type(), length, length)
+			for i := 0; i < length; i++ {
+				f.fill(slice.Index(i), depth+1)
+			}
+			v.Set(slice)
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			f.fill(v.Index(i), depth+1)
+		}
+	case reflect.Map:
+		if depth < maxFillDepth && n%4 != 0 {
+			m := reflect.MakeMap(v.Type())
+			for i := 0; i < n%3; i++ {
+				key := reflect.New(v.Type().Key()).Elem()
+				f.fill(key, depth+1)
+				val := reflect.New(v.Type().Elem()).Elem()
+				f.fill(val, depth+1)
+				m.SetMapIndex(key, val)
+			}
+			v.Set(m)
+		}
+	case reflect.Struct:
+		f.fillStruct(v, n, depth)
+	}
+}
+
+// fillStruct fills the struct value with generated data
+func (f *filler) fillStruct(v reflect.Value, n int, depth int) {
+	typ := v.Type()
+	if typ == reflect.TypeOf(time.Time{}) {
+		v.Set(reflect.ValueOf(time.Unix(int64(n)*3600, 0).UTC())) //nolint:mnd
+		return
+	}
+	// Internal state of standard types is left zero as it may be invalid when filled
+	if isStandardPackage(typ.PkgPath()) {
+		return
+	}
+	for i := 0; i < v.NumField(); i++ {
+		f.fill(v.Field(i), depth+1)
+	}
+}
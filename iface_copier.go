@@ -1,12 +1,20 @@
 package deepcopy
 
 import (
+	"fmt"
 	"reflect"
+	"sync/atomic"
+)
+
+const (
+	// ifaceCopierCacheSize max number of copiers cached by an interface copier
+	ifaceCopierCacheSize = 16
 )
 
 // fromIfaceCopier data structure of copier that copies from an interface
 type fromIfaceCopier struct {
-	ctx *Context
+	ctx   *Context
+	cache ifaceCopierCache
 }
 
 func (c *fromIfaceCopier) init(dst, src reflect.Type) error {
@@ -22,16 +30,22 @@ func (c *fromIfaceCopier) Copy(dst, src reflect.Value) error {
 			return nil
 		}
 	}
-	cp, err := buildCopier(c.ctx, dst.Type(), src.Type())
+	dstType, srcType := dst.Type(), src.Type()
+	if entry := c.cache.get(dstType, srcType); entry != nil {
+		return entry.copier.Copy(dst, src)
+	}
+	cp, err := buildCopier(c.ctx, dstType, srcType)
 	if err != nil {
 		return err
 	}
+	c.cache.add(&ifaceCopierEntry{dstType: dstType, srcType: srcType, copier: cp})
 	return cp.Copy(dst, src)
 }
 
 // toIfaceCopier data structure of copier that copies to an interface
 type toIfaceCopier struct {
-	ctx *Context
+	ctx   *Context
+	cache ifaceCopierCache
 }
 
 func (c *toIfaceCopier) init(dst, src reflect.Type) error {
@@ -48,16 +62,124 @@ func (c *toIfaceCopier) Copy(dst, src reflect.Value) error {
 		}
 	}
 
-	// As `dst` is interface, we clone the `src` and assign back to the `dst`
-	srcType := src.Type()
-	cloneSrc := reflect.New(srcType).Elem()
-	cp, err := buildCopier(c.ctx, srcType, srcType)
-	if err != nil {
+	// Copy into the value held by `dst` if it's a non-nil pointer or map
+	if c.ctx.CopyIntoIfaceValue {
+		if dstVal := dst.Elem(); dstVal.IsValid() && (dstVal.Kind() == reflect.Pointer ||
+			dstVal.Kind() == reflect.Map) && !dstVal.IsNil() {
+			return c.copyIntoValue(dst, dstVal, src)
+		}
+	}
+
+	dstType, srcType := dst.Type(), src.Type()
+	entry := c.cache.get(dstType, srcType)
+	if entry == nil {
+		// As `dst` is interface, we clone the `src` and assign back to the `dst`.
+		// When `src` doesn't implement the interface, copy to a registered implementation instead.
+		newType := srcType
+		if !srcType.Implements(dstType) {
+			if newType = findImplementation(dstType, srcType); newType == nil {
+				if c.ctx.IgnoreNonCopyableTypes {
+					return nil
+				}
+				return fmt.Errorf("%w: %v -> %v (no registered implementation)", ErrTypeNonCopyable, srcType, dstType)
+			}
+		}
+		cp, err := buildCopier(c.ctx, newType, srcType)
+		if err != nil {
+			return err
+		}
+		entry = &ifaceCopierEntry{dstType: dstType, srcType: srcType, newType: newType, copier: cp}
+		c.cache.add(entry)
+	}
+
+	newDst := reflect.New(entry.newType).Elem()
+	if err := entry.copier.Copy(newDst, src); err != nil {
 		return err
 	}
-	if err = cp.Copy(cloneSrc, src); err != nil {
+	dst.Set(newDst)
+	return nil
+}
+
+// copyIntoValue copies `src` into the pointer or map held by `dst`.
+// The pointed value or map entries are updated in place, so the holders of them see the changes.
+func (c *toIfaceCopier) copyIntoValue(dst, dstVal, src reflect.Value) error {
+	dstType, srcType := dstVal.Type(), src.Type()
+	entry := c.cache.get(dstType, srcType)
+	if entry == nil {
+		cp, err := buildCopier(c.ctx, dstType, srcType)
+		if err != nil {
+			return err
+		}
+		entry = &ifaceCopierEntry{dstType: dstType, srcType: srcType, copier: cp}
+		c.cache.add(entry)
+	}
+	// NOTE: values held by interfaces are not addressable, copy into an addressable one
+	newDst := reflect.New(dstType).Elem()
+	newDst.Set(dstVal)
+	if err := entry.copier.Copy(newDst, src); err != nil {
 		return err
 	}
-	dst.Set(cloneSrc)
+	dst.Set(newDst)
+	return nil
+}
+
+// ifaceCopierEntry copier cached by an interface copier for a pair of types
+type ifaceCopierEntry struct {
+	dstType reflect.Type
+	srcType reflect.Type
+	// newType type of new values to copy to, nil when copying into existing values
+	newType reflect.Type
+	copier  copier
+}
+
+// ifaceCopierCache small cache of copiers for the dynamic types seen by an interface copier,
+// it saves building cache keys and locking the copier cache for every value.
+// Reads are lock-free, entries are added via copy-on-write.
+type ifaceCopierCache struct {
+	entries atomic.Value // *[]*ifaceCopierEntry
+}
+
+// get returns the cached copier entry of the types or nil if there is none
+func (c *ifaceCopierCache) get(dstType, srcType reflect.Type) *ifaceCopierEntry {
+	entries, _ := c.entries.Load().(*[]*ifaceCopierEntry)
+	if entries == nil {
+		return nil
+	}
+	for _, entry := range *entries {
+		if entry.srcType == srcType && entry.dstType == dstType {
+			return entry
+		}
+	}
 	return nil
 }
+
+// add adds the copier entry unless the cache is full or has the types already
+func (c *ifaceCopierCache) add(entry *ifaceCopierEntry) {
+	for {
+		oldEntries, _ := c.entries.Load().(*[]*ifaceCopierEntry)
+		var entries []*ifaceCopierEntry
+		if oldEntries != nil {
+			entries = *oldEntries
+		}
+		if len(entries) >= ifaceCopierCacheSize {
+			return
+		}
+		for _, e := range entries {
+			if e.srcType == entry.srcType && e.dstType == entry.dstType {
+				return
+			}
+		}
+		newEntries := make([]*ifaceCopierEntry, len(entries), len(entries)+1)
+		copy(newEntries, entries)
+		newEntries = append(newEntries, entry)
+		if oldEntries == nil {
+			if c.entries.CompareAndSwap(nil, &newEntries) {
+				return
+			}
+			continue
+		}
+		if c.entries.CompareAndSwap(oldEntries, &newEntries) {
+			return
+		}
+	}
+}
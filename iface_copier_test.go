@@ -1,6 +1,8 @@
 package deepcopy
 
 import (
+	"reflect"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -140,6 +142,181 @@ func Test_Copy_iface(t *testing.T) {
 	})
 }
 
+func Test_Copy_iface_into_value(t *testing.T) {
+	type SS struct {
+		I int
+		S string
+	}
+	type DD struct {
+		I int64
+		S string
+		X string
+	}
+
+	t.Run("#1: struct -> iface of struct pointer", func(t *testing.T) {
+		dd := &DD{X: "x"}
+		var d any = dd
+		err := Copy(&d, SS{I: 1, S: "a"}, CopyIntoIfaceValue(true))
+		assert.Nil(t, err)
+		assert.Same(t, dd, d)
+		assert.Equal(t, &DD{I: 1, S: "a", X: "x"}, d)
+	})
+
+	t.Run("#2: struct pointer -> struct field iface of struct pointer", func(t *testing.T) {
+		type S struct {
+			V *SS
+		}
+		type D struct {
+			V any
+		}
+		dd := &DD{X: "x"}
+		d := D{V: dd}
+		err := Copy(&d, S{V: &SS{I: 1, S: "a"}}, CopyIntoIfaceValue(true))
+		assert.Nil(t, err)
+		assert.Same(t, dd, d.V)
+		assert.Equal(t, &DD{I: 1, S: "a", X: "x"}, dd)
+	})
+
+	t.Run("#3: iface of struct -> iface of map", func(t *testing.T) {
+		m := map[string]any{"X": "x"}
+		var d any = m
+		var s any = SS{I: 1, S: "a"}
+		err := Copy(&d, s, CopyIntoIfaceValue(true))
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]any{"I": 1, "S": "a", "X": "x"}, m)
+	})
+
+	t.Run("#4: iface of nil pointer or non-pointer value is replaced", func(t *testing.T) {
+		var d any = (*DD)(nil)
+		err := Copy(&d, SS{I: 1}, CopyIntoIfaceValue(true))
+		assert.Nil(t, err)
+		assert.Equal(t, SS{I: 1}, d)
+
+		d = DD{X: "x"}
+		err = Copy(&d, SS{I: 1}, CopyIntoIfaceValue(true))
+		assert.Nil(t, err)
+		assert.Equal(t, SS{I: 1}, d)
+	})
+
+	t.Run("#5: option not set", func(t *testing.T) {
+		dd := &DD{X: "x"}
+		var d any = dd
+		err := Copy(&d, SS{I: 1, S: "a"})
+		assert.Nil(t, err)
+		assert.Equal(t, SS{I: 1, S: "a"}, d)
+		assert.Equal(t, &DD{X: "x"}, dd)
+	})
+
+	t.Run("#6: non-copyable value", func(t *testing.T) {
+		var d any = &DD{}
+		err := Copy(&d, []int{1}, CopyIntoIfaceValue(true))
+		assert.ErrorIs(t, err, ErrTypeNonCopyable)
+	})
+}
+
+type testShape interface {
+	Area() float64
+}
+
+type testDomainCircle struct {
+	R float64
+}
+
+type testDomainRect struct {
+	W, H float64
+}
+
+type testDomainTriangle struct {
+	A, B, C float64
+}
+
+type testDtoCircle struct {
+	R float64
+}
+
+func (c testDtoCircle) Area() float64 {
+	return 3 * c.R * c.R
+}
+
+type testDtoRect struct {
+	W, H float64
+}
+
+func (r *testDtoRect) Area() float64 {
+	return r.W * r.H
+}
+
+func init() {
+	RegisterImplementation[testDomainCircle, testDtoCircle]()
+	RegisterImplementation[testDomainRect, testDtoRect]()
+	RegisterImplementation[testDomainCircle, testDtoCircle]()
+}
+
+func Test_Copy_iface_implementation(t *testing.T) {
+	t.Run("#1: struct -> iface", func(t *testing.T) {
+		var d testShape
+		err := Copy(&d, testDomainCircle{R: 1})
+		assert.Nil(t, err)
+		assert.Equal(t, testDtoCircle{R: 1}, d)
+
+		err = Copy(&d, &testDomainRect{W: 2, H: 3})
+		assert.Nil(t, err)
+		assert.Equal(t, &testDtoRect{W: 2, H: 3}, d)
+	})
+
+	t.Run("#2: slice and map of ifaces", func(t *testing.T) {
+		s := []any{testDomainCircle{R: 1}, &testDomainRect{W: 2, H: 3}, nil}
+		var d []testShape
+		err := Copy(&d, s)
+		assert.Nil(t, err)
+		assert.Equal(t, []testShape{testDtoCircle{R: 1}, &testDtoRect{W: 2, H: 3}, nil}, d)
+
+		var m map[string]testShape
+		err = Copy(&m, map[string]any{"c": testDomainCircle{R: 1}})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]testShape{"c": testDtoCircle{R: 1}}, m)
+	})
+
+	t.Run("#3: struct field of iface", func(t *testing.T) {
+		type S struct {
+			Shape any
+		}
+		type D struct {
+			Shape testShape
+		}
+		var d D
+		err := Copy(&d, S{Shape: testDomainRect{W: 2, H: 3}})
+		assert.Nil(t, err)
+		assert.Equal(t, D{Shape: &testDtoRect{W: 2, H: 3}}, d)
+	})
+
+	t.Run("#4: source implementing the iface is cloned", func(t *testing.T) {
+		var d testShape
+		err := Copy(&d, testDtoCircle{R: 2})
+		assert.Nil(t, err)
+		assert.Equal(t, testDtoCircle{R: 2}, d)
+
+		var a any
+		err = Copy(&a, testDomainCircle{R: 2})
+		assert.Nil(t, err)
+		assert.Equal(t, testDomainCircle{R: 2}, a)
+	})
+
+	t.Run("#5: no registered implementation", func(t *testing.T) {
+		var d testShape
+		err := Copy(&d, testDomainTriangle{A: 1})
+		assert.ErrorIs(t, err, ErrTypeNonCopyable)
+
+		var ds []testShape
+		err = Copy(&ds, []any{testDomainTriangle{A: 1}})
+		assert.ErrorIs(t, err, ErrTypeNonCopyable)
+
+		err = Copy(&ds, []any{testDomainTriangle{A: 1}}, IgnoreNonCopyableTypes(true))
+		assert.Nil(t, err)
+		assert.Equal(t, []testShape{nil}, ds)
+	})
+}
+
 func Test_Copy_iface_error(t *testing.T) {
 	t.Run("#1: nil interface", func(t *testing.T) {
 		var s any
@@ -163,3 +340,45 @@ func Test_Copy_iface_error(t *testing.T) {
 		assert.ErrorIs(t, err, ErrTypeNonCopyable)
 	})
 }
+
+func Test_ifaceCopierCache(t *testing.T) {
+	t.Run("#1: get and add entries", func(t *testing.T) {
+		var cache ifaceCopierCache
+		assert.Nil(t, cache.get(typeOf[int](), typeOf[int]()))
+
+		entry := &ifaceCopierEntry{dstType: typeOf[int](), srcType: typeOf[int8](), copier: defaultConvCopier}
+		cache.add(entry)
+		cache.add(&ifaceCopierEntry{dstType: typeOf[int](), srcType: typeOf[int8]()})
+		assert.Same(t, entry, cache.get(typeOf[int](), typeOf[int8]()))
+		assert.Nil(t, cache.get(typeOf[int8](), typeOf[int]()))
+	})
+
+	t.Run("#2: cache is full", func(t *testing.T) {
+		var cache ifaceCopierCache
+		types := make([]reflect.Type, 0, ifaceCopierCacheSize+1)
+		for i := 0; i <= ifaceCopierCacheSize; i++ {
+			types = append(types, reflect.ArrayOf(i, typeOf[int]()))
+			cache.add(&ifaceCopierEntry{dstType: types[i], srcType: types[i]})
+		}
+		assert.NotNil(t, cache.get(types[ifaceCopierCacheSize-1], types[ifaceCopierCacheSize-1]))
+		assert.Nil(t, cache.get(types[ifaceCopierCacheSize], types[ifaceCopierCacheSize]))
+	})
+
+	t.Run("#3: concurrent copying", func(t *testing.T) {
+		s := []any{1, "a", 1.5, true, map[string]any{"a": []any{1, "b"}}, []any{nil, int8(1)}}
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					var d []any
+					err := Copy(&d, s)
+					assert.Nil(t, err)
+					assert.Equal(t, s, d)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
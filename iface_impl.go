@@ -0,0 +1,55 @@
+package deepcopy
+
+import (
+	"reflect"
+	"sync"
+)
+
+var (
+	// implMap registered destination types of source types for copying to interfaces
+	implMap = map[reflect.Type][]reflect.Type{}
+
+	// implMu read/write lock of registered implementations
+	implMu sync.RWMutex
+)
+
+// RegisterImplementation registers type D as the implementation to copy values of type S to when copying them
+// to interfaces S doesn't implement, e.g. `RegisterImplementation[domain.Circle, dto.Circle]()` allows copying
+// `domain.Circle` values to `dto.Shape` interfaces, slices of them and maps of them.
+// When D doesn't implement an interface but *D does, values are copied to new *D values.
+// Multiple types can be registered for a source type, the first one implementing the interface is used.
+// This function should be called at program startup, e.g. in `init()`.
+func RegisterImplementation[S, D any]() {
+	srcType, dstType := typeOf[S](), typeOf[D]()
+	implMu.Lock()
+	defer implMu.Unlock()
+	for _, typ := range implMap[srcType] {
+		if typ == dstType {
+			return
+		}
+	}
+	implMap[srcType] = append(implMap[srcType], dstType)
+}
+
+// findImplementation finds the registered type of the source type implementing the interface type,
+// pointers to source types are looked up by their element types. Returns nil if there is none.
+func findImplementation(ifaceType, srcType reflect.Type) reflect.Type {
+	implMu.RLock()
+	defer implMu.RUnlock()
+	if len(implMap) == 0 {
+		return nil
+	}
+	dstTypes := implMap[srcType]
+	if dstTypes == nil && srcType.Kind() == reflect.Pointer {
+		dstTypes = implMap[srcType.Elem()]
+	}
+	for _, typ := range dstTypes {
+		if typ.Implements(ifaceType) {
+			return typ
+		}
+		if ptrType := reflect.PointerTo(typ); ptrType.Implements(ifaceType) {
+			return ptrType
+		}
+	}
+	return nil
+}
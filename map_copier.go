@@ -9,6 +9,10 @@ type mapCopier struct {
 	ctx         *Context
 	keyCopier   *mapItemCopier
 	valueCopier *mapItemCopier
+	// parallelKeyCopier, parallelValueCopier copiers of entries copied in parallel,
+	// they don't copy in parallel again
+	parallelKeyCopier   *mapItemCopier
+	parallelValueCopier *mapItemCopier
 }
 
 // Copy implementation of Copy function for map copier
@@ -20,6 +24,11 @@ func (c *mapCopier) Copy(dst, src reflect.Value) (err error) {
 	if dst.IsNil() {
 		dst.Set(reflect.MakeMapWithSize(dst.Type(), src.Len()))
 	}
+	if c.keyCopier != nil || c.valueCopier != nil {
+		if chunks := c.ctx.parallelChunks(src.Len()); chunks > 1 {
+			return c.copyParallel(dst, src, chunks)
+		}
+	}
 	iter := src.MapRange()
 	for iter.Next() {
 		k := iter.Key()
@@ -39,7 +48,68 @@ func (c *mapCopier) Copy(dst, src reflect.Value) (err error) {
 	return nil
 }
 
-func (c *mapCopier) init(dstType, srcType reflect.Type) error {
+// copyParallel copies map entries in chunks by multiple goroutines.
+// The copied entries are set to `dst` afterward in the calling goroutine as maps can't be written concurrently.
+// When entries fail, the error of the failed entry having the smallest key is returned, so it doesn't depend
+// on the iteration order of the map.
+func (c *mapCopier) copyParallel(dst, src reflect.Value, chunks int) error {
+	n := src.Len()
+	keys, values := make([]reflect.Value, 0, n), make([]reflect.Value, 0, n)
+	iter := src.MapRange()
+	for iter.Next() {
+		keys = append(keys, iter.Key())
+		values = append(values, iter.Value())
+	}
+
+	keyCopier, valueCopier := c.parallelKeyCopier, c.parallelValueCopier
+	// Errors of the entries, failed entries keep their source keys to find the smallest one
+	errs := make([]error, len(keys))
+	err := parallelCopy(len(keys), chunks, func(from, to int) (chunkErr error) {
+		for i := from; i < to; i++ {
+			k, v, err := keys[i], values[i], error(nil)
+			if keyCopier != nil {
+				k, err = keyCopier.Copy(k)
+			}
+			if err == nil && valueCopier != nil {
+				v, err = valueCopier.Copy(v)
+			}
+			if err != nil {
+				errs[i], chunkErr = err, err
+				continue
+			}
+			keys[i], values[i] = k, v
+		}
+		return chunkErr
+	})
+	if err != nil {
+		failed := -1
+		for i := range errs {
+			if errs[i] != nil && (failed < 0 || lessMapKeys(keys[i], keys[failed])) {
+				failed = i
+			}
+		}
+		return errs[failed]
+	}
+
+	for i := range keys {
+		dst.SetMapIndex(keys[i], values[i])
+	}
+	return nil
+}
+
+func (c *mapCopier) init(dstType, srcType reflect.Type) (err error) {
+	c.keyCopier, c.valueCopier, err = buildMapItemCopiers(c.ctx, dstType, srcType)
+	if err != nil || c.ctx.parallelWorkers <= 1 {
+		return err
+	}
+	c.parallelKeyCopier, c.parallelValueCopier, err = buildMapItemCopiers(c.ctx.withoutParallel(), dstType, srcType)
+	return err
+}
+
+// buildMapItemCopiers builds copiers of map keys and values with the context, nil copiers mean
+// keys or values are assigned as is
+func buildMapItemCopiers(ctx *Context, dstType, srcType reflect.Type) (
+	keyCopier, valueCopier *mapItemCopier, err error) {
 	srcKeyType, srcValType := srcType.Key(), srcType.Elem()
 	dstKeyType, dstValType := dstType.Key(), dstType.Elem()
 	buildKeyCopier, buildValCopier := true, true
@@ -47,40 +117,41 @@ func (c *mapCopier) init(dstType, srcType reflect.Type) error {
 	// OPTIMIZATION: buildCopier() can handle this nicely
 	if simpleKindMask&(1<<srcKeyType.Kind()) > 0 {
 		if srcKeyType == dstKeyType {
-			// Just keep c.keyCopier = nil
+			// Just keep keyCopier = nil
 			buildKeyCopier = false
-		} else if srcKeyType.ConvertibleTo(dstKeyType) {
-			c.keyCopier = &mapItemCopier{dstType: dstKeyType, copier: defaultConvCopier}
+		} else if ctx.simpleConvertible(dstKeyType, srcKeyType) {
+			keyCopier = &mapItemCopier{dstType: dstKeyType, copier: defaultConvCopier}
 			buildKeyCopier = false
 		}
 	}
 
 	// OPTIMIZATION: buildCopier() can handle this nicely
-	if simpleKindMask&(1<<srcValType.Kind()) > 0 {
+	if simpleKindMask&(1<<srcValType.Kind()) > 0 && !ctx.hasFieldMasks() {
 		if srcValType == dstValType {
-			// Just keep c.valueCopier = nil
+			// Just keep valueCopier = nil
 			buildValCopier = false
-		} else if srcValType.ConvertibleTo(dstValType) {
-			c.valueCopier = &mapItemCopier{dstType: dstValType, copier: defaultConvCopier}
+		} else if ctx.simpleConvertible(dstValType, srcValType) {
+			valueCopier = &mapItemCopier{dstType: dstValType, copier: defaultConvCopier}
 			buildValCopier = false
 		}
 	}
 
 	if buildKeyCopier {
-		cp, err := buildCopier(c.ctx, dstKeyType, srcKeyType)
+		// Field masks only apply to map values
+		cp, err := buildCopier(ctx.withFieldMasks(nil, nil), dstKeyType, srcKeyType)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
-		c.keyCopier = &mapItemCopier{dstType: dstKeyType, copier: cp}
+		keyCopier = &mapItemCopier{dstType: dstKeyType, copier: cp}
 	}
 	if buildValCopier {
-		cp, err := buildCopier(c.ctx, dstValType, srcValType)
+		cp, err := buildCopier(ctx, dstValType, srcValType)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
-		c.valueCopier = &mapItemCopier{dstType: dstValType, copier: cp}
+		valueCopier = &mapItemCopier{dstType: dstValType, copier: cp}
 	}
-	return nil
+	return keyCopier, valueCopier, nil
 }
 
 // mapItemCopier data structure of copier that copies from a map's key or value
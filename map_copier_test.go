@@ -160,7 +160,7 @@ func Test_Copy_map_error(t *testing.T) {
 		var s map[int]int = map[int]int{1: 1, 2: 2}
 		var d map[int]int
 		cp := &mapCopier{
-			ctx:       defaultContext(),
+			ctx:       testPreparedContext(),
 			keyCopier: &mapItemCopier{copier: &errorCopier{}, dstType: reflect.TypeOf(0)},
 		}
 		err := cp.Copy(reflect.ValueOf(&d).Elem(), reflect.ValueOf(s))
@@ -171,7 +171,7 @@ func Test_Copy_map_error(t *testing.T) {
 		var s map[int]int = map[int]int{1: 1, 2: 2}
 		var d map[int]int
 		cp := &mapCopier{
-			ctx:         defaultContext(),
+			ctx:         testPreparedContext(),
 			valueCopier: &mapItemCopier{copier: &errorCopier{}, dstType: reflect.TypeOf(0)},
 		}
 		err := cp.Copy(reflect.ValueOf(&d).Elem(), reflect.ValueOf(s))
@@ -1,101 +1,79 @@
 package deepcopy
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
-	"strings"
+	"unicode"
+	"unicode/utf8"
 	"unsafe"
 )
 
 // mapToStructCopier data structure of copier that copies a map to a struct
 type mapToStructCopier struct {
-	ctx                     *Context
-	mapDstCopyingMethod     map[string]*reflect.Method
-	mapDstStructFields      map[string]*simpleFieldDetail
-	dstStructRequiredFields int
-	postCopyMethod          *int
+	ctx *Context
+	// entryCopiers copiers of map entries by their keys, they are prepared when the copier is built
+	// so copying entries needs no lookups of fields, methods or copiers
+	entryCopiers   map[string]*mapEntryCopier
+	requiredFields []*simpleFieldDetail
+	postCopyMethod *int
+}
+
+// mapEntryCopier copier of map entries having a key
+type mapEntryCopier struct {
+	copier copier
+	// err error of preparing the copier, it's returned only when a map has an entry with the key
+	err error
+	// requiredIndex index of the destination field in the required fields, -1 if it's not required
+	requiredIndex int
 }
 
 type simpleFieldDetail struct {
+	ctx             *Context
 	fieldType       reflect.Type
 	fieldUnexported bool
 	key             string
 	required        bool
 	nilOnZero       bool
+	shallow         bool
 	index           []int
 }
 
 // Copy implementation of Copy function for map to struct copier
-//
-//nolint:gocognit,gocyclo
 func (c *mapToStructCopier) Copy(dstStruct, srcMap reflect.Value) error {
 	if !srcMap.IsValid() || srcMap.IsNil() {
 		return nil
 	}
 
-	dstStructType := dstStruct.Type()
 	// Marks all fields of the dst struct which require copying
-	var mapCopiedKeys map[string]struct{}
-	if c.dstStructRequiredFields > 0 {
-		mapCopiedKeys = make(map[string]struct{}, c.dstStructRequiredFields)
+	var copiedFields []bool
+	if len(c.requiredFields) > 0 {
+		copiedFields = make([]bool, len(c.requiredFields))
 	}
 
-	// Copies map entries to struct fields
+	// Copies map entries to struct fields or via copying methods
 	iter := srcMap.MapRange()
 	for iter.Next() {
-		key := iter.Key()
-		srcVal := iter.Value()
-		srcValType := srcVal.Type()
-		keyStr := key.String()
-
-		// Copying methods have higher priority, so if a method defined in the destination, use it
-		if c.mapDstCopyingMethod != nil {
-			methodName := "Copy" + strings.ToUpper(keyStr[:1]) + keyStr[1:]
-			dstCpMethod, exists := c.mapDstCopyingMethod[methodName]
-			if exists && !dstCpMethod.Type.In(1).AssignableTo(srcValType) {
-				return fmt.Errorf("%w: struct method '%v.%s' does not accept argument type '%v' from '%v[%s]'",
-					ErrMethodInvalid, dstStructType, dstCpMethod.Name, srcValType, srcMap.Type(), keyStr)
-			}
-			if exists {
-				err := (&methodCopier{dstMethod: dstCpMethod.Index}).Copy(dstStruct, srcVal)
-				if err != nil {
-					return err
-				}
-				continue
-			}
-		}
-
-		// Find field details from `dst` having the key
-		dfDetail := c.mapDstStructFields[keyStr]
-		if dfDetail == nil {
+		entryCopier := c.entryCopiers[iter.Key().String()]
+		if entryCopier == nil {
 			continue
 		}
-
-		entryCopier, err := c.buildCopier(dstStructType, srcValType, dfDetail)
-		if err != nil {
-			return err
+		if entryCopier.err != nil {
+			return entryCopier.err
 		}
-		err = entryCopier.Copy(dstStruct, srcVal)
-		if err != nil {
+		if err := entryCopier.copier.Copy(dstStruct, iter.Value()); err != nil {
 			return err
 		}
-
-		// Marks the field as copied
-		if dfDetail.required {
-			mapCopiedKeys[dfDetail.key] = struct{}{}
+		if entryCopier.requiredIndex >= 0 {
+			copiedFields[entryCopier.requiredIndex] = true
 		}
 	}
 
 	// Checks if any dst field requires copying
-	if c.dstStructRequiredFields > 0 {
-		for _, v := range c.mapDstStructFields {
-			if !v.required {
-				continue
-			}
-			if _, exists := mapCopiedKeys[v.key]; !exists {
-				return fmt.Errorf("%w: struct field '%v[%s]' requires copying",
-					ErrFieldRequireCopying, dstStructType, v.key)
-			}
+	for i, copied := range copiedFields {
+		if !copied {
+			return fmt.Errorf("%w: struct field '%v[%s]' requires copying",
+				ErrFieldRequireCopying, dstStruct.Type(), c.requiredFields[i].key)
 		}
 	}
 
@@ -115,6 +93,7 @@ func (c *mapToStructCopier) Copy(dstStruct, srcMap reflect.Value) error {
 	return nil
 }
 
+//nolint:gocognit,gocyclo
 func (c *mapToStructCopier) init(dstType, srcType reflect.Type) (err error) {
 	mapKeyType, mapValType := srcType.Key(), srcType.Elem()
 	if mapKeyType.Kind() != reflect.String {
@@ -125,14 +104,19 @@ func (c *mapToStructCopier) init(dstType, srcType reflect.Type) (err error) {
 			ErrTypeNonCopyable, mapKeyType, mapValType, dstType)
 	}
 
-	var postCopyMethod *reflect.Method
-	c.mapDstCopyingMethod, postCopyMethod = typeParseMethods(c.ctx, dstType)
+	dstCopyingMethods, postCopyMethod := typeParseMethods(c.ctx, dstType)
 	if postCopyMethod != nil {
 		c.postCopyMethod = &postCopyMethod.Index
 	}
 
 	dstDirectFields, mapDstDirectFields, dstInheritedFields, mapDstInheritedFields := structParseAllFields(dstType)
-	c.mapDstStructFields = make(map[string]*simpleFieldDetail, len(dstDirectFields)+len(dstInheritedFields))
+	c.entryCopiers = make(map[string]*mapEntryCopier, len(dstDirectFields)+len(dstInheritedFields))
+
+	if c.ctx.hasFieldMasks() {
+		if err = c.ctx.fieldMasksCheckKeys(dstType, mapDstDirectFields, mapDstInheritedFields); err != nil {
+			return err
+		}
+	}
 
 	for _, key := range append(dstDirectFields, dstInheritedFields...) {
 		dfDetail := mapDstDirectFields[key]
@@ -142,16 +126,62 @@ func (c *mapToStructCopier) init(dstType, srcType reflect.Type) (err error) {
 		if dfDetail == nil || dfDetail.ignored || dfDetail.done || dfDetail.field.Anonymous {
 			continue
 		}
-		c.mapDstStructFields[dfDetail.key] = &simpleFieldDetail{
+
+		// Skip the field when it's excluded by field masks
+		fieldCtx := c.ctx
+		if c.ctx.hasFieldMasks() {
+			only, omit, included := c.ctx.fieldMasksInclude(dfDetail.key)
+			if !included {
+				continue
+			}
+			fieldCtx = c.ctx.withFieldMasks(only, omit)
+		}
+
+		fieldDetail := &simpleFieldDetail{
+			ctx:             fieldCtx.withTimeFormat(dfDetail.timeFormat),
 			key:             dfDetail.key,
 			fieldType:       dfDetail.field.Type,
 			fieldUnexported: !dfDetail.field.IsExported(),
 			required:        dfDetail.required,
 			nilOnZero:       dfDetail.nilOnZero,
+			shallow:         dfDetail.shallow,
 			index:           dfDetail.index,
 		}
+		// NOTE: map values are always of the map value type, so copiers can be built in advance.
+		// Errors are kept to return when a map has the key as copying maps without it should succeed.
+		entryCopier := &mapEntryCopier{requiredIndex: -1}
+		entryCopier.copier, entryCopier.err = c.buildCopier(dstType, mapValType, fieldDetail)
 		if dfDetail.required {
-			c.dstStructRequiredFields++
+			entryCopier.requiredIndex = len(c.requiredFields)
+			c.requiredFields = append(c.requiredFields, fieldDetail)
+		}
+		c.entryCopiers[dfDetail.key] = entryCopier
+	}
+
+	// Copying methods have higher priority, so if a method defined in the destination, use it.
+	// A method `Copy<Key>` is used for entries of keys `<Key>` and `<key>`.
+	for methodName, dstCpMethod := range dstCopyingMethods {
+		methodKey := methodName[len("Copy"):]
+		if methodKey == "" {
+			continue
+		}
+		keys := []string{methodKey}
+		if first, size := utf8.DecodeRuneInString(methodKey); unicode.ToLower(first) != first {
+			keys = append(keys, string(unicode.ToLower(first))+methodKey[size:])
+		}
+		for _, key := range keys {
+			if c.ctx.hasFieldMasks() {
+				if _, _, included := c.ctx.fieldMasksInclude(key); !included {
+					delete(c.entryCopiers, key)
+					continue
+				}
+			}
+			entryCopier := &mapEntryCopier{copier: &methodCopier{dstMethod: dstCpMethod.Index}, requiredIndex: -1}
+			if !dstCpMethod.Type.In(1).AssignableTo(mapValType) {
+				entryCopier.err = fmt.Errorf("%w: struct method '%v.%s' does not accept argument type '%v' from '%v[%s]'",
+					ErrMethodInvalid, dstType, dstCpMethod.Name, mapValType, srcType, key)
+			}
+			c.entryCopiers[key] = entryCopier
 		}
 	}
 
@@ -160,22 +190,31 @@ func (c *mapToStructCopier) init(dstType, srcType reflect.Type) (err error) {
 
 func (c *mapToStructCopier) buildCopier(dstStructType, srcValType reflect.Type,
 	dstFieldDetail *simpleFieldDetail) (copier, error) {
+	// Shallow copy assigns the value as is
+	if dstFieldDetail.shallow && srcValType.AssignableTo(dstFieldDetail.fieldType) {
+		if dstFieldDetail.ctx.hasFieldMasks() {
+			return nil, dstFieldDetail.ctx.fieldMasksErrNotApplicable(dstFieldDetail.fieldType, srcValType)
+		}
+		return c.createValue2FieldCopier(dstFieldDetail, nil), nil
+	}
+
 	// OPTIMIZATION: buildCopier() can handle this nicely
-	if simpleKindMask&(1<<srcValType.Kind()) > 0 {
+	if simpleKindMask&(1<<srcValType.Kind()) > 0 && !dstFieldDetail.ctx.hasFieldMasks() {
 		if srcValType == dstFieldDetail.fieldType {
 			// NOTE: pass nil to unset custom copier and trigger direct copying.
 			// We can pass `&directCopier{}` for the same result (but it's a bit slower).
 			return c.createValue2FieldCopier(dstFieldDetail, nil), nil
 		}
-		if srcValType.ConvertibleTo(dstFieldDetail.fieldType) {
+		if dstFieldDetail.ctx.simpleConvertible(dstFieldDetail.fieldType, srcValType) {
 			return c.createValue2FieldCopier(dstFieldDetail, defaultConvCopier), nil
 		}
 	}
 
-	cp, err := buildCopier(c.ctx, dstFieldDetail.fieldType, srcValType)
+	cp, err := buildCopier(dstFieldDetail.ctx, dstFieldDetail.fieldType, srcValType)
 	if err != nil {
 		// NOTE: If the copy is not required and the field is unexported, ignore the error
-		if !dstFieldDetail.required && dstFieldDetail.fieldUnexported {
+		// unless the field is given in field masks
+		if !dstFieldDetail.required && dstFieldDetail.fieldUnexported && !errors.Is(err, ErrFieldMaskInvalid) {
 			return defaultNopCopier, nil
 		}
 		return nil, err
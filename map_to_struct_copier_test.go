@@ -251,6 +251,37 @@ func Test_Copy_mapToStruct(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, DD{DD2: DD2{I: 1}}, d)
 	})
+
+	t.Run("#19: map of iface with different dynamic types", func(t *testing.T) {
+		type DD struct {
+			I int
+			S string
+			F float32
+			P *int
+		}
+
+		for i := 0; i < 3; i++ {
+			s := map[string]any{"I": int8(i), "S": "a", "F": i, "P": float64(i), "X": nil}
+			var d DD
+			err := Copy(&d, s)
+			assert.Nil(t, err)
+			p := i
+			assert.Equal(t, DD{I: i, S: "a", F: float32(i), P: &p}, d)
+		}
+	})
+
+	t.Run("#20: recursive struct type", func(t *testing.T) {
+		type DD struct {
+			I     int
+			Child *DD
+		}
+
+		s := map[string]any{"I": 1, "Child": map[string]any{"I": 2, "Child": map[string]any{"I": 3}}}
+		var d DD
+		err := Copy(&d, s)
+		assert.Nil(t, err)
+		assert.Equal(t, DD{I: 1, Child: &DD{I: 2, Child: &DD{I: 3}}}, d)
+	})
 }
 
 func Test_Copy_mapToStruct_error(t *testing.T) {
@@ -418,6 +449,43 @@ func Test_Copy_mapToStruct_method(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, testD3{U: 2}, d)
 	})
+
+	t.Run("#4: map entry of lowercase key -> dst method", func(t *testing.T) {
+		s := map[string]int{"i1": 1, "U": 2}
+		var d testD3
+		err := Copy(&d, s)
+		assert.Nil(t, err)
+		assert.Equal(t, testD3{x1: 2, U: 2}, d)
+	})
+
+	t.Run("#5: map entry excluded by field masks", func(t *testing.T) {
+		s := map[string]int{"I1": 1, "U": 2}
+		var d testD3
+		err := Copy(&d, s, Only("U"))
+		assert.Nil(t, err)
+		assert.Equal(t, testD3{U: 2}, d)
+	})
+	t.Run("#6: map entry of non-ASCII lowercase key -> dst method", func(t *testing.T) {
+		s := map[string]int{"ärger": 1, "Ölig": 2}
+		var d testDUnicode
+		err := Copy(&d, s)
+		assert.Nil(t, err)
+		assert.Equal(t, testDUnicode{x: 1, y: 2}, d)
+	})
+}
+
+type testDUnicode struct {
+	x, y int
+}
+
+func (d *testDUnicode) CopyÄrger(v int) error {
+	d.x = v
+	return nil
+}
+
+func (d *testDUnicode) CopyÖlig(v int) error {
+	d.y = v
+	return nil
 }
 
 func Test_Copy_mapToStruct_method_error(t *testing.T) {
@@ -500,3 +568,46 @@ func Test_Copy_mapToStruct_with_post_copy_event(t *testing.T) {
 		assert.Equal(t, testD8{I: 1, U: 2}, d)
 	})
 }
+
+func Test_Copy_mapToStruct_with_shallow_copy(t *testing.T) {
+	t.Run("#1: shallow copy via field tag", func(t *testing.T) {
+		type DD struct {
+			S  []int `copy:",shallow"`
+			SS []int
+		}
+		s := map[string][]int{"S": {1, 2}, "SS": {1, 2}}
+		var d DD
+		err := Copy(&d, s)
+		assert.Nil(t, err)
+		assert.True(t, &s["S"][0] == &d.S[0])
+		assert.True(t, &s["SS"][0] != &d.SS[0])
+	})
+}
+
+func Benchmark_Copy_mapToStruct(b *testing.B) {
+	type DD struct {
+		ID     int
+		Name   string
+		Price  float64
+		Active bool
+		Tags   []string
+	}
+
+	b.Run("typed map", func(b *testing.B) {
+		s := map[string]int{"ID": 1, "Price": 2, "Active": 3, "Extra": 4}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			var d DD
+			_ = Copy(&d, s)
+		}
+	})
+
+	b.Run("map of iface", func(b *testing.B) {
+		s := map[string]any{"ID": 1, "Name": "a", "Price": 1.5, "Active": true, "Tags": []string{"x"}, "Extra": 4}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			var d DD
+			_ = Copy(&d, s)
+		}
+	})
+}
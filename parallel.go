@@ -0,0 +1,80 @@
+package deepcopy
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+const (
+	// defaultParallelMinChunk default min number of items copied by a goroutine
+	defaultParallelMinChunk = 1024
+)
+
+// parallelChunks returns the number of chunks to split `n` items into for copying them in parallel,
+// 1 means copying them in the calling goroutine
+func (ctx *Context) parallelChunks(n int) int {
+	if ctx.parallelWorkers <= 1 {
+		return 1
+	}
+	chunks := n / ctx.parallelMinChunk
+	if chunks > ctx.parallelWorkers {
+		chunks = ctx.parallelWorkers
+	}
+	if chunks < 1 {
+		return 1
+	}
+	return chunks
+}
+
+// withoutParallel returns a context copying in the calling goroutine only. Copiers of items copied
+// in parallel are built with it, so nested slices and maps don't start more goroutines.
+func (ctx *Context) withoutParallel() *Context {
+	if ctx.parallelWorkers == 0 {
+		return ctx
+	}
+	options := *ctx.copierOptions
+	options.parallelWorkers, options.parallelMinChunk = 0, 0
+	return ctx.withOptions(options)
+}
+
+// parallelCopy splits `n` items into chunks of consecutive ones and calls `copyFn` for every chunk
+// in its own goroutine. Every chunk stops at its first error, the error of the first failed chunk
+// is returned, so it's the one of the failed item having the lowest index.
+func parallelCopy(n, chunks int, copyFn func(from, to int) error) error {
+	errs := make([]error, chunks)
+	var wg sync.WaitGroup
+	wg.Add(chunks)
+	for i := 0; i < chunks; i++ {
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = copyFn(n*i/chunks, n*(i+1)/chunks)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lessMapKeys checks if map key `a` is ordered before map key `b`.
+// Keys of kinds having no order such as structs and pointers are ordered by their formatted texts.
+func lessMapKeys(a, b reflect.Value) bool {
+	switch a.Kind() { //nolint:exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() < b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float()
+	case reflect.String:
+		return a.String() < b.String()
+	case reflect.Bool:
+		return !a.Bool() && b.Bool()
+	}
+	return fmt.Sprint(a) < fmt.Sprint(b)
+}
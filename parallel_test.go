@@ -0,0 +1,200 @@
+package deepcopy
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errTestParallelItem = errors.New("item error")
+
+type testParallelItem struct {
+	ID   int
+	Name string
+	Tags []string
+}
+
+type testParallelDstItem struct {
+	ID   int
+	Name *string
+}
+
+func (d *testParallelDstItem) CopyID(id int) error {
+	if id%100 == 99 {
+		return fmt.Errorf("%w: %d", errTestParallelItem, id)
+	}
+	d.ID = id
+	return nil
+}
+
+func testParallelItems(n int) []testParallelItem {
+	items := make([]testParallelItem, n)
+	for i := range items {
+		items[i] = testParallelItem{ID: i, Name: strconv.Itoa(i), Tags: []string{"a", strconv.Itoa(i)}}
+	}
+	return items
+}
+
+func Test_Copy_parallel(t *testing.T) {
+	t.Run("#1: slice of structs", func(t *testing.T) {
+		s := testParallelItems(1000)
+		var d []testParallelItem
+		err := Copy(&d, s, Parallel(4, 100))
+		assert.Nil(t, err)
+		assert.Equal(t, s, d)
+		d[0].Tags[0] = "x"
+		assert.Equal(t, "a", s[0].Tags[0])
+	})
+
+	t.Run("#2: slice -> array", func(t *testing.T) {
+		s := testParallelItems(300)
+		var d [400]*testParallelItem
+		d[399] = &testParallelItem{}
+		err := Copy(&d, s, Parallel(3, 50))
+		assert.Nil(t, err)
+		for i := range s {
+			assert.Equal(t, s[i], *d[i])
+		}
+		assert.Nil(t, d[399])
+	})
+
+	t.Run("#3: map of structs", func(t *testing.T) {
+		s := make(map[int]testParallelItem, 1000)
+		for _, item := range testParallelItems(1000) {
+			s[item.ID] = item
+		}
+		d := map[IntT]*testParallelItem{-1: {}}
+		err := Copy(&d, s, Parallel(4, 100))
+		assert.Nil(t, err)
+		assert.Equal(t, 1001, len(d))
+		for k, v := range s {
+			assert.Equal(t, v, *d[IntT(k)])
+		}
+	})
+
+	t.Run("#4: too few items to copy in parallel", func(t *testing.T) {
+		s := testParallelItems(10)
+		var d []testParallelItem
+		err := Copy(&d, s, Parallel(4, 100))
+		assert.Nil(t, err)
+		assert.Equal(t, s, d)
+	})
+
+	t.Run("#5: error of the item having the lowest index", func(t *testing.T) {
+		s := testParallelItems(1000)
+		for i := 0; i < 10; i++ {
+			var d []testParallelDstItem
+			err := Copy(&d, s, Parallel(8, 10))
+			assert.ErrorIs(t, err, errTestParallelItem)
+			assert.Equal(t, "item error: 99", err.Error())
+		}
+	})
+
+	t.Run("#6: map copying error", func(t *testing.T) {
+		s := make(map[int]testParallelItem, 1000)
+		for _, item := range testParallelItems(1000) {
+			s[item.ID] = item
+		}
+		d := map[int]testParallelDstItem{}
+		err := Copy(&d, s, Parallel(8, 10))
+		assert.ErrorIs(t, err, errTestParallelItem)
+		assert.Equal(t, 0, len(d))
+	})
+
+	t.Run("#7: nested slices and maps are not copied in parallel again", func(t *testing.T) {
+		s := make([][]testParallelItem, 4)
+		for i := range s {
+			s[i] = testParallelItems(1000)
+		}
+		var d [][]testParallelItem
+		err := Copy(&d, s, Parallel(4, 1))
+		assert.Nil(t, err)
+		assert.Equal(t, s, d)
+
+		ctx := defaultContext()
+		Parallel(4, 1)(ctx)
+		assert.Nil(t, ctx.prepare())
+		cp, err := buildCopier(ctx, typeOf[[][]int](), typeOf[[][]int8]())
+		assert.Nil(t, err)
+		sliceCp := cp.(*sliceCopier)
+		assert.Equal(t, 4, sliceCp.itemCopier.(*sliceCopier).ctx.parallelWorkers)
+		assert.Equal(t, 0, sliceCp.parallelItemCopier.(*sliceCopier).ctx.parallelWorkers)
+
+		cp, err = buildCopier(ctx, typeOf[map[int]map[int]int](), typeOf[map[int]map[int]int8]())
+		assert.Nil(t, err)
+		mapCp := cp.(*mapCopier)
+		assert.Equal(t, 4, mapCp.valueCopier.copier.(*mapCopier).ctx.parallelWorkers)
+		assert.Equal(t, 0, mapCp.parallelValueCopier.copier.(*mapCopier).ctx.parallelWorkers)
+	})
+	t.Run("#8: error of the map entry having the smallest key", func(t *testing.T) {
+		s := make(map[int]testParallelItem, 200)
+		s2 := make(map[string]testParallelItem, 200)
+		for _, item := range testParallelItems(200) {
+			s[item.ID] = item
+			s2[fmt.Sprintf("%03d", item.ID)] = item
+		}
+		// Entries 99 and 199 fail
+		for i := 0; i < 20; i++ {
+			var d map[int]testParallelDstItem
+			err := Copy(&d, s, Parallel(8, 10))
+			assert.ErrorIs(t, err, errTestParallelItem)
+			assert.Equal(t, "item error: 99", err.Error())
+
+			var d2 map[string]testParallelDstItem
+			err = Copy(&d2, s2, Parallel(8, 10))
+			assert.ErrorIs(t, err, errTestParallelItem)
+			assert.Equal(t, "item error: 99", err.Error())
+		}
+	})
+
+}
+
+func Test_Context_parallelChunks(t *testing.T) {
+	ctx := defaultContext()
+	assert.Nil(t, ctx.prepare())
+	assert.Equal(t, 1, ctx.parallelChunks(1000000))
+
+	Parallel(1, 10)(ctx)
+	assert.Nil(t, ctx.prepare())
+	assert.Equal(t, 1, ctx.parallelChunks(1000000))
+
+	Parallel(4, 10)(ctx)
+	assert.Nil(t, ctx.prepare())
+	assert.Equal(t, 1, ctx.parallelChunks(19))
+	assert.Equal(t, 2, ctx.parallelChunks(20))
+	assert.Equal(t, 3, ctx.parallelChunks(39))
+	assert.Equal(t, 4, ctx.parallelChunks(1000))
+
+	Parallel(4, 0)(ctx)
+	assert.Nil(t, ctx.prepare())
+	assert.Equal(t, defaultParallelMinChunk, ctx.parallelMinChunk)
+	assert.Equal(t, 1, ctx.parallelChunks(2*defaultParallelMinChunk-1))
+	assert.Equal(t, 2, ctx.parallelChunks(2*defaultParallelMinChunk))
+}
+
+func Test_parallelCopy(t *testing.T) {
+	t.Run("#1: chunks cover all items", func(t *testing.T) {
+		copied := make([]int, 10)
+		err := parallelCopy(10, 3, func(from, to int) error {
+			for i := from; i < to; i++ {
+				copied[i]++
+			}
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, copied)
+	})
+
+	t.Run("#2: error of the first failed chunk", func(t *testing.T) {
+		err := parallelCopy(10, 5, func(from, to int) error {
+			if from >= 4 {
+				return fmt.Errorf("%w: %d", errTestParallelItem, from)
+			}
+			return nil
+		})
+		assert.Equal(t, "item error: 4", err.Error())
+	})
+}
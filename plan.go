@@ -0,0 +1,303 @@
+package deepcopy
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// CopierKind kind of copier used for copying values
+type CopierKind string
+
+const (
+	// CopierKindDirect values are assigned as is
+	CopierKindDirect CopierKind = "direct"
+	// CopierKindConvert values are converted via Go conversion
+	CopierKindConvert CopierKind = "convert"
+	// CopierKindMethod values are copied via a copying method of the destination struct
+	CopierKindMethod CopierKind = "method"
+	// CopierKindNested values are copied via copying their inner values such as struct fields,
+	// slice items, map entries, pointed values
+	CopierKindNested CopierKind = "nested"
+	// CopierKindInline values are copied via a copier built at copying time as their types
+	// reference themselves
+	CopierKindInline CopierKind = "inline"
+	// CopierKindNop values are not copied
+	CopierKindNop CopierKind = "nop"
+	// CopierKindCustom values are copied via special copiers such as ones of time, text and sql types,
+	// registered copy functions or deep copy methods of the types
+	CopierKindCustom CopierKind = "custom"
+)
+
+// MappingPlan plan of copying values of a source type to a destination type.
+// When the values are structs or pointers, slices, arrays, maps of structs, the plan also describes
+// how fields of the structs are copied.
+type MappingPlan struct {
+	DstType reflect.Type
+	SrcType reflect.Type
+	Kind    CopierKind
+
+	// Fields mappings of struct fields in order of copying
+	Fields []*FieldMapping
+	// PostCopy whether method `PostCopy` of the destination struct is called after copying fields
+	PostCopy bool
+
+	// UnmatchedDstFields paths of the destination fields no source field is copied to
+	UnmatchedDstFields []string
+	// UnmatchedSrcFields paths of the source fields not copied to any destination field or method
+	UnmatchedSrcFields []string
+	// IgnoredDstFields paths of the destination fields ignored by tag `-`
+	IgnoredDstFields []string
+	// IgnoredSrcFields paths of the source fields ignored by tag `-`
+	IgnoredSrcFields []string
+}
+
+// FieldMapping mapping of a source struct field to a destination struct field or copying method
+type FieldMapping struct {
+	// Key copy key of the fields
+	Key string
+	// DstField path of the destination field such as `Base.ID`, empty when copying via a method
+	DstField string
+	// DstMethod name of the destination copying method, empty when copying to a field
+	DstMethod string
+	// SrcField path of the source field
+	SrcField string
+	Kind     CopierKind
+	// NilOnZero whether the destination field is set as `nil` when it's zero after copying
+	NilOnZero bool
+	// Nested plan of copying the field values when they are structs or contain structs
+	Nested *MappingPlan
+}
+
+// Plan returns the plan of copying values of `srcType` to `dstType` with the options.
+// It builds the same copiers as Copy does, so the plan shows which source field or method feeds
+// each destination field and which fields are unmatched or ignored.
+func Plan(dstType, srcType reflect.Type, options ...Option) (*MappingPlan, error) {
+	if dstType == nil || srcType == nil {
+		return nil, fmt.Errorf("%w: source and destination types must be non-nil", ErrTypeInvalid)
+	}
+	ctx := defaultContext()
+	for _, opt := range options {
+		opt(ctx)
+	}
+	if err := ctx.prepare(); err != nil {
+		return nil, err
+	}
+
+	cp, err := buildCopier(ctx, dstType, srcType)
+	if err != nil {
+		return nil, err
+	}
+	return newMappingPlan(dstType, srcType, cp), nil
+}
+
+// newMappingPlan creates mapping plan of the copier built for the types
+func newMappingPlan(dstType, srcType reflect.Type, cp copier) *MappingPlan {
+	plan := &MappingPlan{DstType: dstType, SrcType: srcType, Kind: copierKindOf(cp)}
+	if sc, dstStruct, srcStruct := findStructCopier(cp, dstType, srcType); sc != nil {
+		plan.planFields(sc, dstStruct, srcStruct)
+	}
+	return plan
+}
+
+// planFields describes copying of the struct fields done by the struct copier
+func (plan *MappingPlan) planFields(sc *structCopier, dstType, srcType reflect.Type) {
+	plan.PostCopy = sc.postCopyMethod != nil
+	var dstUsed, srcUsed [][]int
+	for _, cp := range sc.fieldCopiers {
+		switch c := cp.(type) {
+		case *structField2FieldCopier:
+			df, dstPath := structFieldByIndex(dstType, c.dstFieldIndex)
+			sf, srcPath := structFieldByIndex(srcType, c.srcFieldIndex)
+			mapping := &FieldMapping{
+				Key:       fieldKeyOf(sf),
+				DstField:  dstPath,
+				SrcField:  srcPath,
+				Kind:      copierKindOf(c.copier),
+				NilOnZero: c.dstFieldSetNilOnZero,
+			}
+			if nested, _, _ := findStructCopier(c.copier, df.Type, sf.Type); nested != nil {
+				mapping.Nested = newMappingPlan(df.Type, sf.Type, c.copier)
+			}
+			plan.Fields = append(plan.Fields, mapping)
+			dstUsed = append(dstUsed, c.dstFieldIndex)
+			srcUsed = append(srcUsed, c.srcFieldIndex)
+		case *structField2MethodCopier:
+			sf, srcPath := structFieldByIndex(srcType, c.srcFieldIndex)
+			plan.Fields = append(plan.Fields, &FieldMapping{
+				Key:       fieldKeyOf(sf),
+				DstMethod: reflect.PointerTo(dstType).Method(c.dstMethod).Name,
+				SrcField:  srcPath,
+				Kind:      CopierKindMethod,
+			})
+			srcUsed = append(srcUsed, c.srcFieldIndex)
+		}
+	}
+	plan.UnmatchedDstFields, plan.IgnoredDstFields = unusedFields(dstType, nil, "", dstUsed)
+	plan.UnmatchedSrcFields, plan.IgnoredSrcFields = unusedFields(srcType, nil, "", srcUsed)
+}
+
+// String returns human-readable description of the plan
+func (plan *MappingPlan) String() string {
+	var sb strings.Builder
+	plan.write(&sb, "")
+	return sb.String()
+}
+
+// write writes description of the plan with the indent
+func (plan *MappingPlan) write(sb *strings.Builder, indent string) {
+	fmt.Fprintf(sb, "%s <- %s: %s\n", plan.DstType, plan.SrcType, plan.Kind)
+	indent += "  "
+	for _, f := range plan.Fields {
+		dst := f.DstField
+		if f.DstMethod != "" {
+			dst = f.DstMethod + "()"
+		}
+		fmt.Fprintf(sb, "%s%s <- %s: ", indent, dst, f.SrcField)
+		if f.NilOnZero {
+			sb.WriteString("nilonzero ")
+		}
+		if f.Nested == nil {
+			fmt.Fprintf(sb, "%s\n", f.Kind)
+			continue
+		}
+		f.Nested.write(sb, indent)
+	}
+	if plan.PostCopy {
+		fmt.Fprintf(sb, "%s%s()\n", indent, typeMethodPostCopy)
+	}
+	for _, list := range []struct {
+		name   string
+		fields []string
+	}{
+		{"unmatched dst", plan.UnmatchedDstFields},
+		{"unmatched src", plan.UnmatchedSrcFields},
+		{"ignored dst", plan.IgnoredDstFields},
+		{"ignored src", plan.IgnoredSrcFields},
+	} {
+		if len(list.fields) > 0 {
+			fmt.Fprintf(sb, "%s%s: %s\n", indent, list.name, strings.Join(list.fields, ", "))
+		}
+	}
+}
+
+// copierKindOf returns kind of the copier
+func copierKindOf(cp copier) CopierKind {
+	switch cp.(type) {
+	case nil, *directCopier, *bulkCopier:
+		return CopierKindDirect
+	case *convCopier:
+		return CopierKindConvert
+	case *methodCopier, *structField2MethodCopier:
+		return CopierKindMethod
+	case *inlineCopier:
+		return CopierKindInline
+	case *nopCopier:
+		return CopierKindNop
+	case *structCopier, *structToMapCopier, *mapToStructCopier, *sliceCopier, *mapCopier,
+		*value2PtrCopier, *ptr2ValueCopier, *ptr2PtrCopier, *toIfaceCopier, *fromIfaceCopier, *atomicCopier:
+		return CopierKindNested
+	default:
+		return CopierKindCustom
+	}
+}
+
+// findStructCopier finds the struct copier of the copier going through pointers, slices, arrays and maps,
+// returns the struct copier and the struct types it copies or nil if there is none
+func findStructCopier(cp copier, dstType, srcType reflect.Type) (*structCopier, reflect.Type, reflect.Type) {
+	for {
+		switch c := cp.(type) {
+		case *structCopier:
+			return c, dstType, srcType
+		case *value2PtrCopier:
+			cp, dstType = c.copier, dstType.Elem()
+		case *ptr2ValueCopier:
+			cp, srcType = c.copier, srcType.Elem()
+		case *ptr2PtrCopier:
+			cp, dstType, srcType = c.copier, dstType.Elem(), srcType.Elem()
+		case *sliceCopier:
+			cp, dstType, srcType = c.itemCopier, dstType.Elem(), srcType.Elem()
+		case *mapCopier:
+			if c.valueCopier == nil {
+				return nil, nil, nil
+			}
+			cp, dstType, srcType = c.valueCopier.copier, dstType.Elem(), srcType.Elem()
+		default:
+			return nil, nil, nil
+		}
+	}
+}
+
+// structFieldByIndex returns the nested struct field and its path of field names such as `Base.ID`
+func structFieldByIndex(typ reflect.Type, index []int) (reflect.StructField, string) {
+	var field reflect.StructField
+	names := make([]string, 0, len(index))
+	for _, idx := range index {
+		if typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+		field = typ.Field(idx)
+		names = append(names, field.Name)
+		typ = field.Type
+	}
+	return field, strings.Join(names, ".")
+}
+
+// fieldKeyOf returns copy key of the struct field
+func fieldKeyOf(sf reflect.StructField) string {
+	detail := &fieldDetail{field: &sf}
+	parseTag(detail)
+	return detail.key
+}
+
+// unusedFields returns paths of the fields of the struct type which are not used by any field copier
+// and paths of the ignored fields. Embedded structs having used fields are looked into.
+func unusedFields(typ reflect.Type, index []int, pathPrefix string, used [][]int) (unmatched, ignored []string) {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		fieldIndex := append(append(make([]int, 0, len(index)+1), index...), i)
+		path := pathPrefix + sf.Name
+		detail := &fieldDetail{field: &sf}
+		parseTag(detail)
+		if detail.ignored {
+			ignored = append(ignored, path)
+			continue
+		}
+
+		covered, hasUsedInner := false, false
+		for _, usedIndex := range used {
+			switch {
+			case indexHasPrefix(fieldIndex, usedIndex):
+				covered = true
+			case indexHasPrefix(usedIndex, fieldIndex):
+				hasUsedInner = true
+			}
+		}
+		switch {
+		case covered:
+		case hasUsedInner:
+			innerUnmatched, innerIgnored := unusedFields(sf.Type, fieldIndex, path+".", used)
+			unmatched = append(unmatched, innerUnmatched...)
+			ignored = append(ignored, innerIgnored...)
+		default:
+			unmatched = append(unmatched, path)
+		}
+	}
+	return unmatched, ignored
+}
+
+// indexHasPrefix checks if the field index starts with the prefix
+func indexHasPrefix(index, prefix []int) bool {
+	if len(prefix) > len(index) {
+		return false
+	}
+	for i := range prefix {
+		if index[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,186 @@
+package deepcopy
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPlanBase struct {
+	ID      int
+	Version int `copy:"-"`
+}
+
+type testPlanAddr struct {
+	City string
+}
+
+type testPlanSrc struct {
+	testPlanBase
+	Name    string
+	Age     int32
+	Score   int
+	Addr    *testPlanAddr
+	Tags    []string
+	Created time.Time `copy:",layout=RFC3339"`
+	Secret  string    `copy:"-"`
+	Extra   int
+}
+
+type testPlanDst struct {
+	testPlanBase
+	Name    string
+	Age     int64
+	Addr    testPlanAddr
+	Tags    []string `copy:",nilonzero"`
+	Created string
+	Note    string
+
+	score int
+}
+
+func (d *testPlanDst) CopyScore(v int) error {
+	d.score = v
+	return nil
+}
+
+func (d *testPlanDst) PostCopy(src any) error {
+	return nil
+}
+
+type testPlanNode struct {
+	Value int
+	Next  *testPlanNode
+}
+
+func Test_Plan(t *testing.T) {
+	t.Run("#1: plan of struct fields", func(t *testing.T) {
+		plan, err := Plan(typeOf[testPlanDst](), typeOf[testPlanSrc]())
+		assert.Nil(t, err)
+		assert.Equal(t, CopierKindNested, plan.Kind)
+		assert.True(t, plan.PostCopy)
+
+		fields := map[string]*FieldMapping{}
+		for _, f := range plan.Fields {
+			fields[f.Key] = f
+		}
+		assert.Equal(t, 7, len(plan.Fields))
+		assert.Equal(t, &FieldMapping{Key: "testPlanBase", DstField: "testPlanBase", SrcField: "testPlanBase",
+			Kind: CopierKindNested, Nested: fields["testPlanBase"].Nested}, fields["testPlanBase"])
+		assert.Equal(t, &FieldMapping{Key: "Name", DstField: "Name", SrcField: "Name", Kind: CopierKindDirect},
+			fields["Name"])
+		assert.Equal(t, CopierKindConvert, fields["Age"].Kind)
+		assert.Equal(t, &FieldMapping{Key: "Score", DstMethod: "CopyScore", SrcField: "Score",
+			Kind: CopierKindMethod}, fields["Score"])
+		assert.Equal(t, CopierKindCustom, fields["Created"].Kind)
+		assert.True(t, fields["Tags"].NilOnZero)
+
+		addr := fields["Addr"]
+		assert.Equal(t, CopierKindNested, addr.Kind)
+		assert.Equal(t, typeOf[testPlanAddr](), addr.Nested.DstType)
+		assert.Equal(t, typeOf[*testPlanAddr](), addr.Nested.SrcType)
+		assert.Equal(t, 1, len(addr.Nested.Fields))
+		assert.Equal(t, "City", addr.Nested.Fields[0].DstField)
+
+		assert.Equal(t, []string{"Note", "score"}, plan.UnmatchedDstFields)
+		assert.Equal(t, []string{"Extra"}, plan.UnmatchedSrcFields)
+		assert.Equal(t, []string{"Version"}, fields["testPlanBase"].Nested.IgnoredDstFields)
+		assert.Equal(t, []string{"Secret"}, plan.IgnoredSrcFields)
+	})
+
+	t.Run("#2: plan of inherited fields", func(t *testing.T) {
+		type D struct {
+			ID   int
+			Name string
+		}
+		type S struct {
+			*testPlanBase
+			Name string
+		}
+		plan, err := Plan(typeOf[D](), typeOf[S]())
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(plan.Fields))
+		assert.Equal(t, "ID", plan.Fields[1].DstField)
+		assert.Equal(t, "testPlanBase.ID", plan.Fields[1].SrcField)
+		assert.Nil(t, plan.UnmatchedSrcFields)
+		assert.Equal(t, []string{"testPlanBase.Version"}, plan.IgnoredSrcFields)
+	})
+
+	t.Run("#3: plan of recursive struct", func(t *testing.T) {
+		plan, err := Plan(typeOf[testPlanNode](), typeOf[testPlanNode]())
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(plan.Fields))
+		next := plan.Fields[1]
+		assert.Equal(t, CopierKindNested, next.Kind)
+		assert.Nil(t, next.Nested)
+	})
+
+	t.Run("#4: plan of slices of structs", func(t *testing.T) {
+		plan, err := Plan(typeOf[[]testPlanAddr](), typeOf[[]*testPlanAddr]())
+		assert.Nil(t, err)
+		assert.Equal(t, CopierKindNested, plan.Kind)
+		assert.Equal(t, 1, len(plan.Fields))
+		assert.Equal(t, CopierKindDirect, plan.Fields[0].Kind)
+	})
+
+	t.Run("#5: plan of simple types", func(t *testing.T) {
+		plan, err := Plan(typeOf[int](), typeOf[int]())
+		assert.Nil(t, err)
+		assert.Equal(t, &MappingPlan{DstType: typeOf[int](), SrcType: typeOf[int](), Kind: CopierKindDirect}, plan)
+
+		plan, err = Plan(typeOf[int64](), typeOf[int]())
+		assert.Nil(t, err)
+		assert.Equal(t, CopierKindConvert, plan.Kind)
+	})
+
+	t.Run("#6: plan with options", func(t *testing.T) {
+		type S struct {
+			A int
+			B chan int
+		}
+		type D struct {
+			A int
+			B chan int
+		}
+		_, err := Plan(typeOf[D](), typeOf[S]())
+		assert.ErrorIs(t, err, ErrTypeNonCopyable)
+
+		plan, err := Plan(typeOf[D](), typeOf[S](), IgnoreNonCopyableTypes(true))
+		assert.Nil(t, err)
+		assert.Equal(t, CopierKindNop, plan.Fields[1].Kind)
+
+		plan, err = Plan(typeOf[D](), typeOf[S](), Omit("B"))
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(plan.Fields))
+		assert.Equal(t, []string{"B"}, plan.UnmatchedDstFields)
+	})
+
+	t.Run("#7: invalid types", func(t *testing.T) {
+		_, err := Plan(nil, typeOf[int]())
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+	})
+}
+
+func Test_MappingPlan_String(t *testing.T) {
+	plan, err := Plan(typeOf[testPlanDst](), reflect.TypeOf(testPlanSrc{}))
+	assert.Nil(t, err)
+	assert.Equal(t, `deepcopy.testPlanDst <- deepcopy.testPlanSrc: nested
+  testPlanBase <- testPlanBase: deepcopy.testPlanBase <- deepcopy.testPlanBase: nested
+    ID <- ID: direct
+    ignored dst: Version
+    ignored src: Version
+  Name <- Name: direct
+  Age <- Age: convert
+  CopyScore() <- Score: method
+  Addr <- Addr: deepcopy.testPlanAddr <- *deepcopy.testPlanAddr: nested
+    City <- City: direct
+  Tags <- Tags: nilonzero nested
+  Created <- Created: custom
+  PostCopy()
+  unmatched dst: Note, score
+  unmatched src: Extra
+  ignored src: Secret
+`, plan.String())
+}
@@ -2,12 +2,19 @@ package deepcopy
 
 import (
 	"reflect"
+	"unsafe"
 )
 
 // sliceCopier data structure of copier that copies from a `slice`
 type sliceCopier struct {
 	ctx        *Context
 	itemCopier copier
+	// parallelItemCopier copier of items copied in parallel, it doesn't copy in parallel again
+	parallelItemCopier copier
+	// bulkCopy items are of the same type and copied by assigning, they are copied via `reflect.Copy`
+	bulkCopy bool
+	// numConvFunc converts items of numeric types in a typed loop, set when items require conversion
+	numConvFunc numConvFunc
 }
 
 // Copy implementation of Copy function for slice copier
@@ -20,8 +27,8 @@ func (c *sliceCopier) Copy(dst, src reflect.Value) error {
 			return nil
 		}
 		newSlice := reflect.MakeSlice(dst.Type(), srcLen, srcLen)
-		for i := 0; i < srcLen; i++ {
-			if err := c.itemCopier.Copy(newSlice.Index(i), src.Index(i)); err != nil {
+		if !c.copyFast(newSlice, src, srcLen) {
+			if err := c.copyItems(newSlice, src, srcLen); err != nil {
 				return err
 			}
 		}
@@ -34,20 +41,175 @@ func (c *sliceCopier) Copy(dst, src reflect.Value) error {
 	if dstLen < srcLen {
 		srcLen = dstLen
 	}
-	i := 0
-	for ; i < srcLen; i++ {
-		if err := c.itemCopier.Copy(dst.Index(i), src.Index(i)); err != nil {
+	if !c.copyFast(dst, src, srcLen) {
+		if err := c.copyItems(dst, src, srcLen); err != nil {
 			return err
 		}
 	}
-	for ; i < dstLen; i++ {
+	for i := srcLen; i < dstLen; i++ {
 		item := dst.Index(i)
 		item.Set(reflect.Zero(item.Type())) // NOTE: Go1.18 has no SetZero
 	}
 	return nil
 }
 
+// copyItems copies the first `n` items of `src` to `dst` one by one, in parallel when there are many of them
+func (c *sliceCopier) copyItems(dst, src reflect.Value, n int) error {
+	if chunks := c.ctx.parallelChunks(n); chunks > 1 {
+		return parallelCopy(n, chunks, func(from, to int) error {
+			return copySliceItems(c.parallelItemCopier, dst, src, from, to)
+		})
+	}
+	return copySliceItems(c.itemCopier, dst, src, 0, n)
+}
+
+// copySliceItems copies items of `src` to `dst` in the index range [from, to) one by one
+func copySliceItems(itemCopier copier, dst, src reflect.Value, from, to int) error {
+	for i := from; i < to; i++ {
+		if err := itemCopier.Copy(dst.Index(i), src.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyFast copies the first `n` items of `src` to `dst` via bulk copying or typed conversion,
+// returns false if it's not applicable for the values
+func (c *sliceCopier) copyFast(dst, src reflect.Value, n int) bool {
+	if c.bulkCopy {
+		return c.copyBulk(dst, src)
+	}
+	if c.numConvFunc != nil {
+		return c.copyNumConv(dst, src, n)
+	}
+	return false
+}
+
+// copyBulk copies items of `src` to `dst` via `reflect.Copy`, returns false if `src` is inaccessible
+func (c *sliceCopier) copyBulk(dst, src reflect.Value) bool {
+	// NOTE: `reflect.Copy` reads arrays via their addresses, copy unaddressable ones to addressable values
+	if src.Kind() == reflect.Array && !src.CanAddr() {
+		if !src.CanInterface() {
+			return false
+		}
+		newSrc := reflect.New(src.Type()).Elem()
+		newSrc.Set(src)
+		src = newSrc
+	}
+	if !src.CanInterface() {
+		if !src.CanAddr() {
+			return false
+		}
+		src = reflect.NewAt(src.Type(), unsafe.Pointer(src.UnsafeAddr())).Elem() //nolint:gosec
+	}
+	reflect.Copy(dst, src)
+	return true
+}
+
+// copyNumConv converts the first `n` items of `src` to `dst` via the numeric conversion function,
+// returns false if `src` is an unaddressable array
+func (c *sliceCopier) copyNumConv(dst, src reflect.Value, n int) bool {
+	if n == 0 {
+		return true
+	}
+	srcPtr, ok := sliceItemsPointer(src)
+	if !ok {
+		return false
+	}
+	dstPtr, ok := sliceItemsPointer(dst)
+	if !ok {
+		return false
+	}
+	c.numConvFunc(dstPtr, srcPtr, n)
+	return true
+}
+
 func (c *sliceCopier) init(dstType, srcType reflect.Type) (err error) {
-	c.itemCopier, err = buildCopier(c.ctx, dstType.Elem(), srcType.Elem())
-	return
+	dstItemType, srcItemType := dstType.Elem(), srcType.Elem()
+	c.itemCopier, err = buildCopier(c.ctx, dstItemType, srcItemType)
+	if err != nil {
+		return err
+	}
+	switch c.itemCopier.(type) {
+	case *bulkCopier:
+		c.bulkCopy = true
+	case *directCopier:
+		// Items are of simple kinds or shallow copy types, they can be copied as a whole
+		c.bulkCopy = dstItemType == srcItemType
+	case *convCopier:
+		c.numConvFunc = numConvFuncOf(dstItemType.Kind(), srcItemType.Kind())
+	}
+	if c.ctx.parallelWorkers > 1 {
+		c.parallelItemCopier, err = buildCopier(c.ctx.withoutParallel(), dstItemType, srcItemType)
+	}
+	return err
+}
+
+// sliceItemsPointer returns pointer to the first item of a slice or an addressable array
+func sliceItemsPointer(v reflect.Value) (unsafe.Pointer, bool) {
+	if v.Kind() == reflect.Slice {
+		return v.UnsafePointer(), true
+	}
+	if !v.CanAddr() {
+		return nil, false
+	}
+	return unsafe.Pointer(v.UnsafeAddr()), true //nolint:gosec
+}
+
+// numConvFunc function converts `n` numbers from `src` to `dst`
+type numConvFunc func(dst, src unsafe.Pointer, n int)
+
+type number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// numConvFuncs numeric conversion functions indexed by destination and source kinds.
+// NOTE: conversions of numeric types depend on their kinds only.
+var numConvFuncs = [reflect.Float64 + 1][reflect.Float64 + 1]numConvFunc{
+	reflect.Int:     numConvFuncsTo[int](),
+	reflect.Int8:    numConvFuncsTo[int8](),
+	reflect.Int16:   numConvFuncsTo[int16](),
+	reflect.Int32:   numConvFuncsTo[int32](),
+	reflect.Int64:   numConvFuncsTo[int64](),
+	reflect.Uint:    numConvFuncsTo[uint](),
+	reflect.Uint8:   numConvFuncsTo[uint8](),
+	reflect.Uint16:  numConvFuncsTo[uint16](),
+	reflect.Uint32:  numConvFuncsTo[uint32](),
+	reflect.Uint64:  numConvFuncsTo[uint64](),
+	reflect.Float32: numConvFuncsTo[float32](),
+	reflect.Float64: numConvFuncsTo[float64](),
+}
+
+// numConvFuncOf returns the conversion function of numeric kinds, nil if either kind is not numeric
+func numConvFuncOf(dstKind, srcKind reflect.Kind) numConvFunc {
+	if dstKind > reflect.Float64 || srcKind > reflect.Float64 {
+		return nil
+	}
+	return numConvFuncs[dstKind][srcKind]
+}
+
+// numConvFuncsTo returns conversion functions to type D indexed by source kinds
+func numConvFuncsTo[D number]() (funcs [reflect.Float64 + 1]numConvFunc) {
+	funcs[reflect.Int] = convertNumbers[D, int]
+	funcs[reflect.Int8] = convertNumbers[D, int8]
+	funcs[reflect.Int16] = convertNumbers[D, int16]
+	funcs[reflect.Int32] = convertNumbers[D, int32]
+	funcs[reflect.Int64] = convertNumbers[D, int64]
+	funcs[reflect.Uint] = convertNumbers[D, uint]
+	funcs[reflect.Uint8] = convertNumbers[D, uint8]
+	funcs[reflect.Uint16] = convertNumbers[D, uint16]
+	funcs[reflect.Uint32] = convertNumbers[D, uint32]
+	funcs[reflect.Uint64] = convertNumbers[D, uint64]
+	funcs[reflect.Float32] = convertNumbers[D, float32]
+	funcs[reflect.Float64] = convertNumbers[D, float64]
+	return funcs
+}
+
+func convertNumbers[D, S number](dst, src unsafe.Pointer, n int) {
+	dstItems := unsafe.Slice((*D)(dst), n)
+	for i, v := range unsafe.Slice((*S)(src), n) {
+		dstItems[i] = D(v)
+	}
 }
@@ -84,7 +84,7 @@ func Test_Copy_slice_error(t *testing.T) {
 	t.Run("#4: slice item copier returns error", func(t *testing.T) {
 		var s []int = []int{1, 2, 3}
 		var d []int
-		cp := &sliceCopier{ctx: defaultContext(), itemCopier: &errorCopier{}}
+		cp := &sliceCopier{ctx: testPreparedContext(), itemCopier: &errorCopier{}}
 		err := cp.Copy(reflect.ValueOf(&d).Elem(), reflect.ValueOf(s))
 		assert.ErrorIs(t, err, errTest)
 	})
@@ -92,7 +92,7 @@ func Test_Copy_slice_error(t *testing.T) {
 	t.Run("#5: array item copier returns error", func(t *testing.T) {
 		var s [3]int = [3]int{1, 2, 3}
 		var d [3]int
-		cp := &sliceCopier{ctx: defaultContext(), itemCopier: &errorCopier{}}
+		cp := &sliceCopier{ctx: testPreparedContext(), itemCopier: &errorCopier{}}
 		err := cp.Copy(reflect.ValueOf(&d).Elem(), reflect.ValueOf(s))
 		assert.ErrorIs(t, err, errTest)
 	})
@@ -174,3 +174,153 @@ func Test_Copy_array_error(t *testing.T) {
 		assert.ErrorIs(t, err, ErrTypeNonCopyable)
 	})
 }
+
+func Test_Copy_slice_bulk(t *testing.T) {
+	t.Run("#1: slice of int/str -> slice of the same type", func(t *testing.T) {
+		s := []int{1, 2, 3}
+		var d []int
+		err := Copy(&d, s)
+		assert.Nil(t, err)
+		assert.Equal(t, []int{1, 2, 3}, d)
+		d[0] = 10
+		assert.Equal(t, 1, s[0])
+
+		s2 := []string{"a", "b", ""}
+		var d2 []string
+		err = Copy(&d2, s2)
+		assert.Nil(t, err)
+		assert.Equal(t, s2, d2)
+	})
+
+	t.Run("#2: array of byte -> slice of byte", func(t *testing.T) {
+		s := [4]byte{1, 2, 3, 4}
+		var d []byte
+		err := Copy(&d, s)
+		assert.Nil(t, err)
+		assert.Equal(t, []byte{1, 2, 3, 4}, d)
+
+		type SS struct {
+			B [3]byte
+			b [3]byte
+		}
+		type DD struct {
+			B []byte
+			b []byte
+		}
+		var dd DD
+		err = Copy(&dd, &SS{B: [3]byte{1, 2, 3}, b: [3]byte{4, 5, 6}})
+		assert.Nil(t, err)
+		assert.Equal(t, DD{B: []byte{1, 2, 3}, b: []byte{4, 5, 6}}, dd)
+	})
+
+	t.Run("#3: slice of str -> array of str", func(t *testing.T) {
+		d := [4]string{"x", "x", "x", "x"}
+		err := Copy(&d, []string{"a", "b"})
+		assert.Nil(t, err)
+		assert.Equal(t, [4]string{"a", "b", "", ""}, d)
+
+		var d2 [1]string
+		err = Copy(&d2, []string{"a", "b"})
+		assert.Nil(t, err)
+		assert.Equal(t, [1]string{"a"}, d2)
+	})
+
+	t.Run("#4: slice of func -> slice of func", func(t *testing.T) {
+		fn := func() int { return 1 }
+		var d [2]func() int
+		err := Copy(&d, [1]func() int{fn})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, d[0]())
+		assert.Nil(t, d[1])
+	})
+}
+
+func Test_Copy_slice_numeric_conversion(t *testing.T) {
+	t.Run("#1: slice of int32 -> slice of int64", func(t *testing.T) {
+		var d []int64
+		err := Copy(&d, []int32{1, -2, 3})
+		assert.Nil(t, err)
+		assert.Equal(t, []int64{1, -2, 3}, d)
+	})
+
+	t.Run("#2: conversions between int, uint and float", func(t *testing.T) {
+		var d1 []int
+		err := Copy(&d1, []float64{1.5, -2.7})
+		assert.Nil(t, err)
+		assert.Equal(t, []int{1, -2}, d1)
+
+		var d2 []float32
+		err = Copy(&d2, []uint8{1, 255})
+		assert.Nil(t, err)
+		assert.Equal(t, []float32{1, 255}, d2)
+
+		var d3 []uint8
+		err = Copy(&d3, []int{256, 257, -1})
+		assert.Nil(t, err)
+		assert.Equal(t, []uint8{0, 1, 255}, d3)
+	})
+
+	t.Run("#3: derived types", func(t *testing.T) {
+		var d []IntT
+		err := Copy(&d, []int16{1, 2})
+		assert.Nil(t, err)
+		assert.Equal(t, []IntT{1, 2}, d)
+
+		var d2 []int
+		err = Copy(&d2, []IntT{3, 4})
+		assert.Nil(t, err)
+		assert.Equal(t, []int{3, 4}, d2)
+	})
+
+	t.Run("#4: arrays", func(t *testing.T) {
+		d := [4]int64{9, 9, 9, 9}
+		err := Copy(&d, [2]int32{1, 2})
+		assert.Nil(t, err)
+		assert.Equal(t, [4]int64{1, 2, 0, 0}, d)
+
+		var d2 [1]float64
+		err = Copy(&d2, []int{1, 2})
+		assert.Nil(t, err)
+		assert.Equal(t, [1]float64{1}, d2)
+
+		var d3 []uint16
+		err = Copy(&d3, [3]int{1, 2, 3})
+		assert.Nil(t, err)
+		assert.Equal(t, []uint16{1, 2, 3}, d3)
+	})
+
+	t.Run("#5: unexported fields", func(t *testing.T) {
+		type SS struct {
+			ii []int32
+			aa [2]uint
+		}
+		type DD struct {
+			ii []int64
+			aa []float64
+		}
+		var d DD
+		err := Copy(&d, &SS{ii: []int32{1, 2}, aa: [2]uint{3, 4}})
+		assert.Nil(t, err)
+		assert.Equal(t, DD{ii: []int64{1, 2}, aa: []float64{3, 4}}, d)
+	})
+
+	t.Run("#6: empty and nil slices", func(t *testing.T) {
+		d := []int64{1}
+		err := Copy(&d, []int32{})
+		assert.Nil(t, err)
+		assert.Equal(t, []int64{}, d)
+
+		err = Copy(&d, []int32(nil))
+		assert.Nil(t, err)
+		assert.Nil(t, d)
+	})
+}
+
+func Test_numConvFuncOf(t *testing.T) {
+	assert.NotNil(t, numConvFuncOf(reflect.Int64, reflect.Int32))
+	assert.NotNil(t, numConvFuncOf(reflect.Float32, reflect.Uint))
+	assert.Nil(t, numConvFuncOf(reflect.String, reflect.Int))
+	assert.Nil(t, numConvFuncOf(reflect.Int, reflect.Bool))
+	assert.Nil(t, numConvFuncOf(reflect.Uintptr, reflect.Int))
+	assert.Nil(t, numConvFuncOf(reflect.Complex128, reflect.Float64))
+}
@@ -0,0 +1,196 @@
+package deepcopy
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// sqlScanner the same interface as sql.Scanner
+type sqlScanner interface {
+	Scan(src any) error
+}
+
+var (
+	sqlValuerType  = typeOf[driver.Valuer]()
+	sqlScannerType = typeOf[sqlScanner]()
+)
+
+// sqlNullTypeValueField returns the value field of a `sql.Null*` type such as sql.NullString, sql.Null[T]
+func sqlNullTypeValueField(typ reflect.Type) (reflect.Type, bool) {
+	if typ.Kind() != reflect.Struct || typ.PkgPath() != "database/sql" || !strings.HasPrefix(typ.Name(), "Null") {
+		return nil, false
+	}
+	if typ.NumField() != 2 || typ.Field(1).Name != "Valid" || typ.Field(1).Type.Kind() != reflect.Bool {
+		return nil, false
+	}
+	return typ.Field(0).Type, true
+}
+
+// buildCopierForSQLNullTypes builds copier for copying between `sql.Null*` types and other types,
+// returns nil if none of the types is a `sql.Null*` type
+func buildCopierForSQLNullTypes(ctx *Context, dstType, srcType reflect.Type) (copier, error) {
+	if dstType == srcType || dstType.Kind() == reflect.Interface {
+		return nil, nil
+	}
+	srcValType, srcNull := sqlNullTypeValueField(srcType)
+	if !srcNull {
+		// Copying from pointers and interfaces to `sql.Null*` types is handled by the relevant copiers
+		if srcType.Kind() == reflect.Pointer || srcType.Kind() == reflect.Interface {
+			return nil, nil
+		}
+		srcValType = srcType
+	}
+
+	cp := &sqlNullCopier{srcNull: srcNull}
+	dstValType := dstType
+	if srcNull && dstType.Kind() == reflect.Pointer {
+		if dstType.Elem() == srcType {
+			return nil, nil
+		}
+		cp.dstPtr = true
+		dstValType = dstType.Elem()
+	}
+	var dstNullValType reflect.Type
+	if dstNullValType, cp.dstNull = sqlNullTypeValueField(dstValType); cp.dstNull {
+		dstValType = dstNullValType
+	}
+	if !cp.srcNull && !cp.dstNull {
+		return nil, nil
+	}
+	// Structs looking like `sql.Null*` types are copied field by field as usual,
+	// e.g. between sql.NullString and struct { String string; Valid bool }
+	otherType := srcValType
+	if cp.srcNull {
+		otherType = dstValType
+	}
+	otherStruct := !(cp.srcNull && cp.dstNull) && otherType.Kind() == reflect.Struct
+	if otherStruct {
+		if field, ok := otherType.FieldByName("Valid"); ok && field.Type.Kind() == reflect.Bool {
+			return nil, nil
+		}
+	}
+
+	var err error
+	cp.copier, err = buildCopier(ctx, dstValType, srcValType)
+	if err != nil {
+		if otherStruct && errors.Is(err, ErrTypeNonCopyable) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return cp, nil
+}
+
+// sqlNullCopier data structure of copier that copies from or to a `sql.Null*` type.
+// Invalid `sql.Null*` values are copied as zero values or `nil`.
+type sqlNullCopier struct {
+	srcNull bool
+	dstNull bool
+	dstPtr  bool
+	copier  copier
+}
+
+// Copy implementation of Copy function for sql null copier
+func (c *sqlNullCopier) Copy(dst, src reflect.Value) error {
+	if c.srcNull {
+		if !src.Field(1).Bool() {
+			dst.Set(reflect.Zero(dst.Type())) // NOTE: Go1.18 has no SetZero
+			return nil
+		}
+		src = src.Field(0)
+	}
+	if c.dstPtr {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		dst = dst.Elem()
+	}
+	if !c.dstNull {
+		return c.copier.Copy(dst, src)
+	}
+	if err := c.copier.Copy(dst.Field(0), src); err != nil {
+		return err
+	}
+	dst.Field(1).SetBool(true)
+	return nil
+}
+
+// buildCopierForSQLValuerScanner builds copier that bridges types implementing `driver.Valuer`
+// or `sql.Scanner`, returns nil if the types don't implement them
+func buildCopierForSQLValuerScanner(ctx *Context, dstType, srcType reflect.Type) copier {
+	srcValuer := srcType.Implements(sqlValuerType)
+	srcPtrValuer := !srcValuer && srcType.Kind() != reflect.Pointer &&
+		reflect.PointerTo(srcType).Implements(sqlValuerType)
+	dstScanner := reflect.PointerTo(dstType).Implements(sqlScannerType)
+	if !srcValuer && !srcPtrValuer && !dstScanner {
+		return nil
+	}
+	return &sqlValuerScannerCopier{
+		ctx:          ctx,
+		srcValuer:    srcValuer,
+		srcPtrValuer: srcPtrValuer,
+		dstScanner:   dstScanner,
+	}
+}
+
+// isSQLValuerToScanner checks if the source type implements `driver.Valuer` and the destination type
+// implements `sql.Scanner` while the types are not convertible to each other
+func isSQLValuerToScanner(dstType, srcType reflect.Type) bool {
+	return (srcType.Implements(sqlValuerType) || reflect.PointerTo(srcType).Implements(sqlValuerType)) &&
+		reflect.PointerTo(dstType).Implements(sqlScannerType) && !srcType.ConvertibleTo(dstType)
+}
+
+// sqlValuerScannerCopier data structure of copier that copies values via `driver.Valuer` of the source
+// and `sql.Scanner` of the destination
+type sqlValuerScannerCopier struct {
+	ctx          *Context
+	srcValuer    bool
+	srcPtrValuer bool
+	dstScanner   bool
+}
+
+// Copy implementation of Copy function for sql valuer/scanner copier
+func (c *sqlValuerScannerCopier) Copy(dst, src reflect.Value) (err error) {
+	var val any
+	switch {
+	case c.srcValuer:
+		if src.Kind() == reflect.Pointer && src.IsNil() {
+			break
+		}
+		val, err = src.Interface().(driver.Valuer).Value() //nolint:forcetypeassert
+	case c.srcPtrValuer:
+		if !src.CanAddr() {
+			srcCopy := reflect.New(src.Type()).Elem()
+			srcCopy.Set(src)
+			src = srcCopy
+		}
+		val, err = src.Addr().Interface().(driver.Valuer).Value() //nolint:forcetypeassert
+	default:
+		for (src.Kind() == reflect.Pointer || src.Kind() == reflect.Interface) && !src.IsNil() {
+			src = src.Elem()
+		}
+		if src.Kind() != reflect.Pointer && src.Kind() != reflect.Interface {
+			val = src.Interface()
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if c.dstScanner {
+		return dst.Addr().Interface().(sqlScanner).Scan(val) //nolint:forcetypeassert
+	}
+
+	valVal := reflect.ValueOf(val)
+	if !valVal.IsValid() {
+		dst.Set(reflect.Zero(dst.Type())) // NOTE: Go1.18 has no SetZero
+		return nil
+	}
+	cp, err := buildCopier(c.ctx, dst.Type(), valVal.Type())
+	if err != nil {
+		return err
+	}
+	return cp.Copy(dst, valVal)
+}
@@ -0,0 +1,41 @@
+//go:build go1.22
+
+package deepcopy
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Copy_sqlNullTypes_go1_22(t *testing.T) {
+	t.Run("#1: sql.Null[T] <-> values and pointers", func(t *testing.T) {
+		type A struct {
+			I int
+		}
+		type B struct {
+			I int64
+		}
+		type SS struct {
+			I sql.Null[int]
+			A sql.Null[A]
+			N sql.Null[A]
+		}
+		type DD struct {
+			I *int32
+			A *B
+			N *B
+		}
+		s := SS{I: sql.Null[int]{V: 1, Valid: true}, A: sql.Null[A]{V: A{I: 2}, Valid: true}}
+		var d DD
+		err := Copy(&d, s)
+		assert.Nil(t, err)
+		assert.Equal(t, DD{I: ptrOf(int32(1)), A: &B{I: 2}}, d)
+
+		var s2 SS
+		err = Copy(&s2, d)
+		assert.Nil(t, err)
+		assert.Equal(t, s, s2)
+	})
+}
@@ -0,0 +1,232 @@
+package deepcopy
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testMoney implements driver.Valuer
+type testMoney struct {
+	cents int64
+}
+
+func (m testMoney) Value() (driver.Value, error) {
+	if m.cents < 0 {
+		return nil, errTest
+	}
+	return m.cents, nil
+}
+
+// testID implements sql.Scanner
+type testID struct {
+	v string
+}
+
+func (id *testID) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		id.v = ""
+	case string:
+		id.v = v
+	case int64:
+		id.v = strconv.FormatInt(v, 10)
+	default:
+		return fmt.Errorf("%w: unsupported type %T", errTest, src)
+	}
+	return nil
+}
+
+func Test_Copy_sqlNullTypes(t *testing.T) {
+	now := time.Now()
+
+	t.Run("#1: sql.Null* -> values and pointers", func(t *testing.T) {
+		type SS struct {
+			S  sql.NullString
+			PS sql.NullString
+			I  sql.NullInt64
+			PI sql.NullInt32
+			T  sql.NullTime
+			PT sql.NullTime
+		}
+		type DD struct {
+			S  string
+			PS *string
+			I  int
+			PI *int64
+			T  time.Time
+			PT *time.Time
+		}
+		s := SS{
+			S:  sql.NullString{String: "abc", Valid: true},
+			PS: sql.NullString{String: "xyz", Valid: true},
+			I:  sql.NullInt64{Int64: 123, Valid: true},
+			T:  sql.NullTime{Time: now, Valid: true},
+		}
+		d := DD{PI: ptrOf(int64(1)), PT: &now}
+		err := Copy(&d, s)
+		assert.Nil(t, err)
+		assert.Equal(t, DD{S: "abc", PS: ptrOf("xyz"), I: 123, T: now}, d)
+	})
+
+	t.Run("#2: values and pointers -> sql.Null*", func(t *testing.T) {
+		type SS struct {
+			S  string
+			PS *string
+			I  int
+			PI *int64
+			PT *time.Time
+		}
+		type DD struct {
+			S  sql.NullString
+			PS sql.NullString
+			I  sql.NullInt64
+			PI sql.NullInt32
+			PT sql.NullTime
+		}
+		s := SS{S: "abc", I: 123, PI: ptrOf(int64(1)), PT: &now}
+		d := DD{PS: sql.NullString{String: "xyz", Valid: true}}
+		err := Copy(&d, s)
+		assert.Nil(t, err)
+		assert.Equal(t, DD{
+			S:  sql.NullString{String: "abc", Valid: true},
+			I:  sql.NullInt64{Int64: 123, Valid: true},
+			PI: sql.NullInt32{Int32: 1, Valid: true},
+			PT: sql.NullTime{Time: now, Valid: true},
+		}, d)
+	})
+
+	t.Run("#3: sql.Null* -> sql.Null*", func(t *testing.T) {
+		var d sql.NullInt64
+		err := Copy(&d, sql.NullInt32{Int32: 12, Valid: true})
+		assert.Nil(t, err)
+		assert.Equal(t, sql.NullInt64{Int64: 12, Valid: true}, d)
+
+		err = Copy(&d, sql.NullInt32{Int32: 12})
+		assert.Nil(t, err)
+		assert.Equal(t, sql.NullInt64{}, d)
+	})
+
+	t.Run("#4: sql.Null* with nilonzero", func(t *testing.T) {
+		type SS struct {
+			S1 sql.NullString
+			S2 sql.NullString
+			S3 sql.NullString
+		}
+		type DD struct {
+			S1 *string `copy:",nilonzero"`
+			S2 *string `copy:",nilonzero"`
+			S3 *string
+		}
+		s := SS{S1: sql.NullString{String: "", Valid: true}, S2: sql.NullString{String: "a", Valid: true},
+			S3: sql.NullString{String: "", Valid: true}}
+		var d DD
+		err := Copy(&d, s)
+		assert.Nil(t, err)
+		assert.Equal(t, DD{S2: ptrOf("a"), S3: ptrOf("")}, d)
+	})
+
+	t.Run("#5: sql.Null* -> non-copyable types", func(t *testing.T) {
+		var d []int
+		err := Copy(&d, sql.NullString{String: "abc", Valid: true})
+		assert.ErrorIs(t, err, ErrTypeNonCopyable)
+	})
+
+	t.Run("#6: sql.Null* <-> lookalike structs", func(t *testing.T) {
+		type MyNull struct {
+			String string
+			Valid  bool
+		}
+		var d MyNull
+		err := Copy(&d, sql.NullString{String: "x", Valid: true})
+		assert.Nil(t, err)
+		assert.Equal(t, MyNull{String: "x", Valid: true}, d)
+
+		var d2 sql.NullString
+		err = Copy(&d2, MyNull{String: "y", Valid: true})
+		assert.Nil(t, err)
+		assert.Equal(t, sql.NullString{String: "y", Valid: true}, d2)
+
+		var d3 *MyNull
+		err = Copy(&d3, sql.NullString{String: "z"})
+		assert.Nil(t, err)
+		assert.Equal(t, &MyNull{String: "z"}, d3)
+
+		// Structs having no `Valid` field are copied field by field too
+		type MyString struct {
+			String string
+		}
+		var d4 MyString
+		err = Copy(&d4, sql.NullString{String: "x", Valid: true})
+		assert.Nil(t, err)
+		assert.Equal(t, MyString{String: "x"}, d4)
+
+		var d5 sql.NullString
+		err = Copy(&d5, MyString{String: "y"})
+		assert.Nil(t, err)
+		assert.Equal(t, sql.NullString{String: "y"}, d5)
+	})
+}
+
+func Test_Copy_sqlValuerScanner(t *testing.T) {
+	t.Run("#1: driver.Valuer -> values", func(t *testing.T) {
+		type SS struct {
+			M  testMoney
+			PM *testMoney
+			NM *testMoney
+		}
+		type DD struct {
+			M  int64
+			PM float64
+			NM *int64 `copy:",nilonzero"`
+		}
+		var d DD
+		err := Copy(&d, SS{M: testMoney{cents: 123}, PM: &testMoney{cents: 456}})
+		assert.Nil(t, err)
+		assert.Equal(t, DD{M: 123, PM: 456}, d)
+	})
+
+	t.Run("#2: values -> sql.Scanner", func(t *testing.T) {
+		type SS struct {
+			ID  string
+			PID *int64
+			NID *int64
+		}
+		type DD struct {
+			ID  testID
+			PID testID
+			NID testID
+		}
+		d := DD{NID: testID{v: "x"}}
+		err := Copy(&d, SS{ID: "abc", PID: ptrOf(int64(123))})
+		assert.Nil(t, err)
+		assert.Equal(t, DD{ID: testID{v: "abc"}, PID: testID{v: "123"}}, d)
+	})
+
+	t.Run("#3: driver.Valuer -> sql.Scanner", func(t *testing.T) {
+		var d testID
+		err := Copy(&d, testMoney{cents: 123})
+		assert.Nil(t, err)
+		assert.Equal(t, testID{v: "123"}, d)
+	})
+
+	t.Run("#4: errors from driver.Valuer and sql.Scanner", func(t *testing.T) {
+		var d int64
+		err := Copy(&d, testMoney{cents: -1})
+		assert.ErrorIs(t, err, errTest)
+
+		var d2 testID
+		err = Copy(&d2, 1.5)
+		assert.ErrorIs(t, err, errTest)
+
+		var d3 []int
+		err = Copy(&d3, testMoney{cents: 1})
+		assert.True(t, errors.Is(err, ErrTypeNonCopyable))
+	})
+}
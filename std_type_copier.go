@@ -0,0 +1,94 @@
+package deepcopy
+
+import (
+	"bytes"
+	"math/big"
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	// standardTypeCopiers special copiers for Go standard types keyed by the types.
+	// They apply when copying to the same types or derived ones.
+	standardTypeCopiers = map[reflect.Type]copier{
+		// Big numbers hold internal slices, copy them via the types' own methods
+		typeOf[big.Int]():   &stdTypeCopier[big.Int]{copyFn: func(dst, src *big.Int) { dst.Set(src) }},
+		typeOf[big.Float](): &stdTypeCopier[big.Float]{copyFn: func(dst, src *big.Float) { dst.Copy(src) }},
+		typeOf[big.Rat]():   &stdTypeCopier[big.Rat]{copyFn: func(dst, src *big.Rat) { dst.Set(src) }},
+
+		// Buffers are rebuilt with their unread content
+		typeOf[bytes.Buffer](): &stdTypeCopier[bytes.Buffer]{copyFn: func(dst, src *bytes.Buffer) {
+			dst.Reset()
+			dst.Write(src.Bytes())
+		}},
+		// NOTE: strings.Builder holds a pointer to itself to detect copying by value
+		typeOf[strings.Builder](): &stdTypeCopier[strings.Builder]{copyFn: func(dst, src *strings.Builder) {
+			*dst = strings.Builder{}
+			dst.WriteString(src.String())
+		}},
+
+		typeOf[net.IP](): &stdTypeCopier[net.IP]{copyFn: func(dst, src *net.IP) {
+			if *src == nil {
+				*dst = nil
+				return
+			}
+			*dst = append(make(net.IP, 0, len(*src)), *src...)
+		}},
+
+		// Immutable values are copied as is
+		typeOf[netip.Addr]():     defaultDirectCopier,
+		typeOf[netip.AddrPort](): defaultDirectCopier,
+		typeOf[netip.Prefix]():   defaultDirectCopier,
+		typeOf[url.URL]():        defaultDirectCopier, // NOTE: url.Userinfo is immutable
+		typeOf[regexp.Regexp]():  defaultDirectCopier,
+
+		// Pointers to immutable values are shared.
+		// NOTE: time.Local must keep its identity as it's lazily initialized.
+		typeOf[*regexp.Regexp](): defaultDirectCopier,
+		typeOf[*time.Location](): defaultDirectCopier,
+	}
+)
+
+// buildCopierForStandardTypes builds special copier for Go standard types,
+// returns nil if the types are not handled specially
+func buildCopierForStandardTypes(dstType, srcType reflect.Type) copier {
+	cp := standardTypeCopiers[srcType]
+	if cp == nil {
+		return nil
+	}
+	if dstType == srcType {
+		return cp
+	}
+	if !dstType.ConvertibleTo(srcType) {
+		return nil
+	}
+	if cp == defaultDirectCopier {
+		return defaultConvCopier
+	}
+	return cp
+}
+
+// stdTypeCopier data structure of copier that copies a Go standard type via a typed function
+type stdTypeCopier[T any] struct {
+	copyFn func(dst, src *T)
+}
+
+// Copy implementation of Copy function for standard type copier.
+// NOTE: `dst` can be of a type derived from T.
+func (c *stdTypeCopier[T]) Copy(dst, src reflect.Value) error {
+	if !src.CanAddr() {
+		srcCopy := reflect.New(src.Type()).Elem()
+		srcCopy.Set(src)
+		src = srcCopy
+	}
+	ptrType := reflect.TypeOf((*T)(nil))
+	dstPtr := dst.Addr().Convert(ptrType).Interface().(*T) //nolint:forcetypeassert
+	srcPtr := src.Addr().Convert(ptrType).Interface().(*T) //nolint:forcetypeassert
+	c.copyFn(dstPtr, srcPtr)
+	return nil
+}
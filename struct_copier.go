@@ -1,6 +1,7 @@
 package deepcopy
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -48,6 +49,14 @@ func (c *structCopier) init(dstType, srcType reflect.Type) (err error) {
 	srcDirectFields, mapSrcDirectFields, srcInheritedFields, mapSrcInheritedFields := structParseAllFields(srcType)
 	c.fieldCopiers = make([]copier, 0, len(dstDirectFields)+len(dstInheritedFields))
 
+	if c.ctx.hasFieldMasks() {
+		err = c.ctx.fieldMasksCheckKeys(dstType,
+			mapDstDirectFields, mapDstInheritedFields, mapSrcDirectFields, mapSrcInheritedFields)
+		if err != nil {
+			return err
+		}
+	}
+
 	for _, key := range append(srcDirectFields, srcInheritedFields...) {
 		// Find field details from `src` having the key
 		sfDetail := mapSrcDirectFields[key]
@@ -58,6 +67,16 @@ func (c *structCopier) init(dstType, srcType reflect.Type) (err error) {
 			continue
 		}
 
+		// Skip the field when it's excluded by field masks
+		fieldCtx := c.ctx
+		if c.ctx.hasFieldMasks() {
+			only, omit, included := c.ctx.fieldMasksInclude(key)
+			if !included {
+				continue
+			}
+			fieldCtx = c.ctx.withFieldMasks(only, omit)
+		}
+
 		// Copying methods have higher priority, so if a method defined in the dst struct, use it
 		if dstCopyingMethods != nil {
 			methodName := "Copy" + strings.ToUpper(key[:1]) + key[1:]
@@ -87,7 +106,8 @@ func (c *structCopier) init(dstType, srcType reflect.Type) (err error) {
 			continue
 		}
 
-		copier, err := c.buildCopier(dstType, srcType, dfDetail, sfDetail)
+		fieldCtx = fieldCtx.withTimeFormat(dfDetail.timeFormat.merge(sfDetail.timeFormat))
+		copier, err := c.buildCopier(fieldCtx, dstType, srcType, dfDetail, sfDetail)
 		if err != nil {
 			return err
 		}
@@ -98,43 +118,106 @@ func (c *structCopier) init(dstType, srcType reflect.Type) (err error) {
 
 	// Remaining dst fields can't be copied
 	for _, dfDetail := range mapDstDirectFields {
-		if !dfDetail.done && dfDetail.required {
+		if !dfDetail.done && dfDetail.required && c.fieldIncluded(dfDetail.key) {
 			return fmt.Errorf("%w: struct field '%v[%s]' requires copying",
 				ErrFieldRequireCopying, dstType, dfDetail.field.Name)
 		}
 	}
 	for _, dfDetail := range mapDstInheritedFields {
-		if !dfDetail.done && dfDetail.required {
+		if !dfDetail.done && dfDetail.required && c.fieldIncluded(dfDetail.key) {
 			return fmt.Errorf("%w: struct field '%v[%s]' requires copying",
 				ErrFieldRequireCopying, dstType, dfDetail.field.Name)
 		}
 	}
 
+	// Strict matching requires all exported fields to be copied, the struct tag can override the setting
+	strictMode := c.ctx.StrictMatching
+	if mode, found := structParseStrictMode(srcType); found {
+		strictMode = mode
+	}
+	if mode, found := structParseStrictMode(dstType); found {
+		strictMode = mode
+	}
+	var unmatchedFields []string
+	if strictMode&StrictModeSrc > 0 {
+		unmatchedFields = c.appendUnmatchedFields(unmatchedFields, "source", srcType,
+			srcDirectFields, mapSrcDirectFields, srcInheritedFields, mapSrcInheritedFields)
+	}
+	if strictMode&StrictModeDst > 0 {
+		unmatchedFields = c.appendUnmatchedFields(unmatchedFields, "destination", dstType,
+			dstDirectFields, mapDstDirectFields, dstInheritedFields, mapDstInheritedFields)
+	}
+	if len(unmatchedFields) > 0 {
+		return fmt.Errorf("%w: %s", ErrFieldUnmatched, strings.Join(unmatchedFields, ", "))
+	}
+
 	return nil
 }
 
+// fieldIncluded checks if a field is not excluded by field masks
+func (c *structCopier) fieldIncluded(key string) bool {
+	_, _, included := c.ctx.fieldMasksInclude(key)
+	return included
+}
+
+// appendUnmatchedFields appends descriptions of the exported fields not copied.
+// Embedded structs are not counted as their fields are checked instead, so are inherited fields
+// shadowed by direct ones.
+func (c *structCopier) appendUnmatchedFields(
+	unmatchedFields []string,
+	side string,
+	structType reflect.Type,
+	directKeys []string, mapDirectFields map[string]*fieldDetail,
+	inheritedKeys []string, mapInheritedFields map[string]*fieldDetail,
+) []string {
+	for _, key := range append(directKeys, inheritedKeys...) {
+		detail := mapDirectFields[key]
+		if detail == nil {
+			detail = mapInheritedFields[key]
+		}
+		if detail.done || !detail.field.IsExported() || len(detail.nestedFields) > 0 || !c.fieldIncluded(key) {
+			continue
+		}
+		// Make sure each field is described once
+		detail.done = true
+		unmatchedFields = append(unmatchedFields, fmt.Sprintf("%s field '%v[%s]'", side, structType, detail.field.Name))
+	}
+	return unmatchedFields
+}
+
 func (c *structCopier) buildCopier(
+	ctx *Context,
 	dstStructType, srcStructType reflect.Type,
 	dstFieldDetail, srcFieldDetail *fieldDetail,
 ) (copier, error) {
 	df, sf := dstFieldDetail.field, srcFieldDetail.field
 
+	// Shallow copy assigns the field value as is
+	if (dstFieldDetail.shallow || srcFieldDetail.shallow) && sf.Type.AssignableTo(df.Type) {
+		if ctx.hasFieldMasks() {
+			return nil, ctx.fieldMasksErrNotApplicable(df.Type, sf.Type)
+		}
+		return c.createField2FieldCopier(dstFieldDetail, srcFieldDetail, nil), nil
+	}
+
 	// OPTIMIZATION: buildCopier() can handle this nicely
-	if simpleKindMask&(1<<sf.Type.Kind()) > 0 {
+	if simpleKindMask&(1<<sf.Type.Kind()) > 0 && !ctx.hasFieldMasks() {
 		if sf.Type == df.Type {
 			// NOTE: pass nil to unset custom copier and trigger direct copying.
 			// We can pass `&directCopier{}` for the same result (but it's a bit slower).
 			return c.createField2FieldCopier(dstFieldDetail, srcFieldDetail, nil), nil
 		}
-		if sf.Type.ConvertibleTo(df.Type) {
+		if ctx.simpleConvertible(df.Type, sf.Type) {
 			return c.createField2FieldCopier(dstFieldDetail, srcFieldDetail, defaultConvCopier), nil
 		}
 	}
 
-	cp, err := buildCopier(c.ctx, df.Type, sf.Type)
+	cp, err := buildCopier(ctx, df.Type, sf.Type)
 	if err != nil {
 		// NOTE: If the copy is not required and the field is unexported, ignore the error
-		if !dstFieldDetail.required && !srcFieldDetail.required && !df.IsExported() {
+		// unless the field is given in field masks
+		if !dstFieldDetail.required && !srcFieldDetail.required && !df.IsExported() &&
+			!errors.Is(err, ErrFieldMaskInvalid) {
 			return defaultNopCopier, nil
 		}
 		return nil, err
@@ -1,6 +1,7 @@
 package deepcopy
 
 import (
+	"sync"
 	"testing"
 	"time"
 	"unsafe"
@@ -1229,6 +1230,111 @@ func (d testD25) PostCopy(src any) error {
 	return nil
 }
 
+func Test_Copy_struct_with_strict_matching(t *testing.T) {
+	type Base struct {
+		ID      int
+		Version int
+	}
+	type S struct {
+		Base
+		Name   string
+		Email  string
+		Secret string `copy:"-"`
+		note   string
+	}
+	type D struct {
+		ID    int
+		Name  string
+		Phone string
+		note  string
+	}
+
+	t.Run("#1: not strict by default", func(t *testing.T) {
+		var d D
+		err := Copy(&d, S{Base: Base{ID: 1}, Name: "a"})
+		assert.Nil(t, err)
+		assert.Equal(t, D{ID: 1, Name: "a"}, d)
+	})
+
+	t.Run("#2: all unmatched fields are listed", func(t *testing.T) {
+		var d D
+		err := Copy(&d, S{}, StrictMatching(StrictModeAll))
+		assert.ErrorIs(t, err, ErrFieldUnmatched)
+		assert.Equal(t, "ErrFieldUnmatched: source field 'deepcopy.S[Email]', "+
+			"source field 'deepcopy.S[Version]', destination field 'deepcopy.D[Phone]'", err.Error())
+	})
+
+	t.Run("#3: check only one side", func(t *testing.T) {
+		var d D
+		err := Copy(&d, S{}, StrictMatching(StrictModeDst))
+		assert.ErrorIs(t, err, ErrFieldUnmatched)
+		assert.Equal(t, "ErrFieldUnmatched: destination field 'deepcopy.D[Phone]'", err.Error())
+
+		err = Copy(&d, S{}, StrictMatching(StrictModeSrc), Omit("Email", "Version"))
+		assert.Nil(t, err)
+	})
+
+	t.Run("#4: all fields matched", func(t *testing.T) {
+		type D2 struct {
+			Base
+			Name  string
+			Email string
+		}
+		var d D2
+		err := Copy(&d, S{Base: Base{ID: 1}, Email: "e"}, StrictMatching(StrictModeAll))
+		assert.Nil(t, err)
+		assert.Equal(t, D2{Base: Base{ID: 1}, Email: "e"}, d)
+	})
+
+	t.Run("#5: fields copied via methods are matched", func(t *testing.T) {
+		type SS struct {
+			I1 int
+			U  uint
+		}
+		var d testD1
+		err := Copy(&d, SS{I1: 1, U: 2}, StrictMatching(StrictModeAll))
+		assert.Nil(t, err)
+		assert.Equal(t, testD1{x1: 2, U: 2}, d)
+	})
+
+	t.Run("#6: strict mode set by struct tag", func(t *testing.T) {
+		type D2 struct {
+			_    struct{} `copy:",strict=dst"`
+			ID   int
+			Name string
+			Age  int
+		}
+		var d D2
+		err := Copy(&d, S{})
+		assert.ErrorIs(t, err, ErrFieldUnmatched)
+		assert.Equal(t, "ErrFieldUnmatched: destination field 'deepcopy.D2[Age]'", err.Error())
+
+		type S2 struct {
+			_    struct{} `copy:",strict"`
+			ID   int
+			Name string
+			Tags []string
+		}
+		var d2 D
+		err = Copy(&d2, S2{})
+		assert.ErrorIs(t, err, ErrFieldUnmatched)
+		assert.Equal(t, "ErrFieldUnmatched: source field 'deepcopy.S2[Tags]', "+
+			"destination field 'deepcopy.D[Phone]'", err.Error())
+	})
+
+	t.Run("#7: struct tag overrides the option", func(t *testing.T) {
+		type D2 struct {
+			_    struct{} `copy:",strict=none"`
+			Name string
+			Age  int
+		}
+		var d D2
+		err := Copy(&d, S{Name: "a"}, StrictMatching(StrictModeAll))
+		assert.Nil(t, err)
+		assert.Equal(t, "a", d.Name)
+	})
+}
+
 func Test_Copy_struct_with_post_copy_event(t *testing.T) {
 	t.Run("#1: success without error", func(t *testing.T) {
 		s := testS2{I: 1, S: "a"}
@@ -1279,6 +1385,89 @@ func Test_Copy_struct_with_post_copy_event(t *testing.T) {
 	})
 }
 
+func Test_Copy_struct_with_shallow_copy(t *testing.T) {
+	type Table struct {
+		M map[string]int
+	}
+
+	t.Run("#1: shallow copy via field tag", func(t *testing.T) {
+		type SS struct {
+			P  *Table `copy:",shallow"`
+			S  []int
+			M  map[string]int
+			PP *Table
+		}
+		type DD struct {
+			P  *Table
+			S  []int          `copy:",shallow"`
+			M  map[string]int `copy:",shallow"`
+			PP *Table
+		}
+		s := SS{P: &Table{}, S: []int{1, 2}, M: map[string]int{"a": 1}, PP: &Table{}}
+		var d DD
+		err := Copy(&d, s)
+		assert.Nil(t, err)
+		assert.True(t, s.P == d.P)
+		assert.True(t, &s.S[0] == &d.S[0])
+		d.M["b"] = 2
+		assert.Equal(t, 2, s.M["b"])
+		assert.True(t, s.PP != d.PP)
+	})
+
+	t.Run("#2: shallow copy on unassignable types does deep copy", func(t *testing.T) {
+		type SS struct {
+			S []int `copy:",shallow"`
+		}
+		type DD struct {
+			S []int64
+		}
+		var d DD
+		err := Copy(&d, SS{S: []int{1, 2}})
+		assert.Nil(t, err)
+		assert.Equal(t, DD{S: []int64{1, 2}}, d)
+	})
+
+	t.Run("#3: shallow copy via option", func(t *testing.T) {
+		type SS struct {
+			P  *Table
+			PP []*Table
+			MP map[string]*Table
+			T  Table
+		}
+		s := SS{P: &Table{}, PP: []*Table{{}}, MP: map[string]*Table{"a": {}}, T: Table{M: map[string]int{}}}
+		var d SS
+		err := Copy(&d, s, ShallowCopyType[*Table]())
+		assert.Nil(t, err)
+		assert.True(t, s.P == d.P)
+		assert.True(t, s.PP[0] == d.PP[0])
+		assert.True(t, s.MP["a"] == d.MP["a"])
+		d.T.M["a"] = 1
+		assert.Equal(t, 0, len(s.T.M))
+
+		err = Copy(&d, s, ShallowCopyType[*Table](), ShallowCopyType[Table]())
+		assert.Nil(t, err)
+		d.T.M["a"] = 1
+		assert.Equal(t, 1, len(s.T.M))
+
+		// Without the option, values are deeply copied
+		d = SS{}
+		err = Copy(&d, s)
+		assert.Nil(t, err)
+		assert.True(t, s.P != d.P)
+		assert.True(t, s.PP[0] != d.PP[0])
+		assert.True(t, s.MP["a"] != d.MP["a"])
+	})
+
+	t.Run("#4: shallow copy with field masks", func(t *testing.T) {
+		type SS struct {
+			P *Table `copy:",shallow"`
+		}
+		var d SS
+		err := Copy(&d, SS{P: &Table{}}, Only("P.M"))
+		assert.ErrorIs(t, err, ErrFieldMaskInvalid)
+	})
+}
+
 func Test_Copy_struct_on_standard_types(t *testing.T) {
 	t.Run("#1: Copy time.Time to time.Time", func(t *testing.T) {
 		s := time.Now()
@@ -1323,5 +1512,71 @@ func Test_Copy_struct_on_standard_types(t *testing.T) {
 		assert.Equal(t, time.Time(d2.T), s2.T)
 	})
 
+	t.Run("#3: Copy sync types resets them", func(t *testing.T) {
+		type S struct {
+			sync.Mutex
+			I  int
+			RW sync.RWMutex
+			O  sync.Once
+			WG sync.WaitGroup
+		}
+		s := &S{I: 1}
+		s.Lock()
+		s.RW.RLock()
+		s.O.Do(func() {})
+		s.WG.Add(1)
+
+		var d S
+		err := Copy(&d, s)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, d.I)
+		assert.True(t, d.TryLock())
+		assert.True(t, d.RW.TryLock())
+		called := false
+		d.O.Do(func() { called = true })
+		assert.True(t, called)
+		d.WG.Wait()
+	})
+
+	t.Run("#4: Copy sync.Cond keeps its locker", func(t *testing.T) {
+		type S struct {
+			Mu   sync.Mutex
+			C    *sync.Cond
+			Cond sync.Cond
+		}
+		s := &S{}
+		s.C = sync.NewCond(&s.Mu)
+		s.Cond.L = &s.Mu
+
+		// The destination has its own locker
+		var d S
+		d.Cond.L = &d.Mu
+		err := Copy(&d, s)
+		assert.Nil(t, err)
+		assert.True(t, d.Cond.L == &d.Mu)
+		// The destination has no locker, the source one is shared
+		assert.True(t, d.C.L == &s.Mu)
+		assert.True(t, d.C != s.C)
+
+		done := make(chan struct{})
+		go func() {
+			d.Cond.L.Lock()
+			d.Cond.Wait()
+			d.Cond.L.Unlock()
+			close(done)
+		}()
+		for {
+			d.Cond.L.Lock()
+			d.Cond.Broadcast()
+			d.Cond.L.Unlock()
+			select {
+			case <-done:
+				return
+			case <-time.After(time.Millisecond):
+			}
+		}
+	})
+
 	// NOTE: unique.Handle[T] is available from Go1.23, it is tested in the relevant test file
+	// NOTE: atomic.Int64, atomic.Pointer[T], ... are available from Go1.19, they are tested in the relevant test file
 }
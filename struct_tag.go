@@ -5,6 +5,17 @@ import (
 	"strings"
 )
 
+var (
+	// strictModes values of tag option `strict` of blank struct fields
+	strictModes = map[string]StrictMode{
+		"":     StrictModeAll,
+		"all":  StrictModeAll,
+		"src":  StrictModeSrc,
+		"dst":  StrictModeDst,
+		"none": StrictModeNone,
+	}
+)
+
 // fieldDetail stores field copying detail parsed from a struct field
 type fieldDetail struct {
 	field     *reflect.StructField
@@ -12,6 +23,9 @@ type fieldDetail struct {
 	ignored   bool
 	required  bool
 	nilOnZero bool
+	shallow   bool
+
+	timeFormat timeFormat
 
 	done         bool
 	index        []int
@@ -34,7 +48,7 @@ func parseTag(detail *fieldDetail) {
 		return
 	}
 
-	tags := strings.Split(tagValue, ",")
+	tags := splitTag(tagValue)
 	switch {
 	case tags[0] == "-":
 		detail.ignored = true
@@ -43,7 +57,8 @@ func parseTag(detail *fieldDetail) {
 	}
 
 	for _, tagOpt := range tags[1:] {
-		switch tagOpt {
+		optName, optValue := cutTagOption(tagOpt)
+		switch optName {
 		case "required":
 			if !detail.ignored {
 				detail.required = true
@@ -54,6 +69,71 @@ func parseTag(detail *fieldDetail) {
 			if k == reflect.Pointer || k == reflect.Interface || k == reflect.Slice || k == reflect.Map {
 				detail.nilOnZero = true
 			}
+		case "shallow":
+			detail.shallow = true
+		case "layout":
+			// Named layouts such as `RFC3339` or custom layouts such as `2006-01-02`
+			detail.timeFormat.layout = optValue
+			if layout, found := timeLayouts[optValue]; found {
+				detail.timeFormat.layout = layout
+			}
+		case "unix":
+			detail.timeFormat.unixUnit = timeUnixUnits[optValue]
+		case "utc":
+			detail.timeFormat.utc = true
+		}
+	}
+}
+
+// splitTag splits a tag value into the key and options separated by commas.
+// Commas within single quotes such as `layout='Mon, 02 Jan 2006'` don't separate options.
+func splitTag(tagValue string) []string {
+	tags := make([]string, 0, strings.Count(tagValue, ",")+1)
+	start, quoted := 0, false
+	for i := 0; i < len(tagValue); i++ {
+		switch tagValue[i] {
+		case '\'':
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				tags = append(tags, tagValue[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(tags, tagValue[start:])
+}
+
+// cutTagOption splits a tag option into its name and value, single quotes around the value are removed
+func cutTagOption(tagOpt string) (name, value string) {
+	name, value, _ = strings.Cut(tagOpt, "=")
+	if n := len(value); n >= 2 && value[0] == '\'' && value[n-1] == '\'' {
+		value = value[1 : n-1]
+	}
+	return name, value
+}
+
+// structParseStrictMode parses strict mode of the struct set via tag option `strict` of a blank field
+// such as `_ struct{}` with tag `copy:",strict=dst"`. Unknown values of the option mean `all`.
+func structParseStrictMode(typ reflect.Type) (mode StrictMode, found bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if sf.Name != "_" {
+			continue
+		}
+		tagValue, ok := sf.Tag.Lookup(defaultTagName)
+		if !ok {
+			continue
+		}
+		for _, tagOpt := range splitTag(tagValue)[1:] {
+			optName, optValue := cutTagOption(tagOpt)
+			if optName != "strict" {
+				continue
+			}
+			if mode, found = strictModes[optValue]; !found {
+				mode, found = StrictModeAll, true
+			}
 		}
 	}
+	return mode, found
 }
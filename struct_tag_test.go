@@ -3,6 +3,7 @@ package deepcopy
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -14,6 +15,12 @@ func Test_parseTag(t *testing.T) {
 		Col3 string `copy:"-"`
 		Col4 string `copy:""`
 		Col5 string `copy:",unsupported"`
+		Col6 *int   `copy:",shallow"`
+		Col7 string `copy:",layout=RFC3339,utc"`
+		Col8 int64  `copy:",unix=ms"`
+		Col9 string `copy:",layout=2006-01-02"`
+		ColA string `copy:"colA,layout='Mon, 02 Jan 2006',utc"`
+		ColB string `copy:",layout='RFC3339'"`
 	}
 	structType := reflect.TypeOf(Item{})
 
@@ -41,4 +48,73 @@ func Test_parseTag(t *testing.T) {
 	detail5 := &fieldDetail{field: &col5}
 	parseTag(detail5)
 	assert.True(t, detail5.key == "Col5" && !detail5.required)
+
+	col6, _ := structType.FieldByName("Col6")
+	detail6 := &fieldDetail{field: &col6}
+	parseTag(detail6)
+	assert.True(t, detail6.key == "Col6" && detail6.shallow)
+
+	col7, _ := structType.FieldByName("Col7")
+	detail7 := &fieldDetail{field: &col7}
+	parseTag(detail7)
+	assert.Equal(t, timeFormat{layout: time.RFC3339, utc: true}, detail7.timeFormat)
+
+	col8, _ := structType.FieldByName("Col8")
+	detail8 := &fieldDetail{field: &col8}
+	parseTag(detail8)
+	assert.Equal(t, timeFormat{unixUnit: time.Millisecond}, detail8.timeFormat)
+
+	col9, _ := structType.FieldByName("Col9")
+	detail9 := &fieldDetail{field: &col9}
+	parseTag(detail9)
+	assert.Equal(t, timeFormat{layout: "2006-01-02"}, detail9.timeFormat)
+
+	colA, _ := structType.FieldByName("ColA")
+	detailA := &fieldDetail{field: &colA}
+	parseTag(detailA)
+	assert.Equal(t, "colA", detailA.key)
+	assert.Equal(t, timeFormat{layout: "Mon, 02 Jan 2006", utc: true}, detailA.timeFormat)
+
+	colB, _ := structType.FieldByName("ColB")
+	detailB := &fieldDetail{field: &colB}
+	parseTag(detailB)
+	assert.Equal(t, timeFormat{layout: time.RFC3339}, detailB.timeFormat)
+}
+
+func Test_splitTag(t *testing.T) {
+	assert.Equal(t, []string{""}, splitTag(""))
+	assert.Equal(t, []string{"a", "required", "layout=RFC3339"}, splitTag("a,required,layout=RFC3339"))
+	assert.Equal(t, []string{"", "layout='Mon, 02 Jan 2006'", "utc"}, splitTag(",layout='Mon, 02 Jan 2006',utc"))
+	// Unterminated quotes extend to the end
+	assert.Equal(t, []string{"", "layout='a,utc"}, splitTag(",layout='a,utc"))
+}
+
+func Test_structParseStrictMode(t *testing.T) {
+	type S1 struct {
+		A int
+	}
+	type S2 struct {
+		_ struct{} `copy:",strict"`
+	}
+	type S3 struct {
+		_ struct{} `copy:",strict=src"`
+		_ int      `json:"-"`
+	}
+	type S4 struct {
+		_ struct{} `copy:",strict=none"`
+	}
+	type S5 struct {
+		_ struct{} `copy:",strict=unknown"`
+	}
+
+	mode, found := structParseStrictMode(reflect.TypeOf(S1{}))
+	assert.True(t, mode == StrictModeNone && !found)
+	mode, found = structParseStrictMode(reflect.TypeOf(S2{}))
+	assert.True(t, mode == StrictModeAll && found)
+	mode, found = structParseStrictMode(reflect.TypeOf(S3{}))
+	assert.True(t, mode == StrictModeSrc && found)
+	mode, found = structParseStrictMode(reflect.TypeOf(S4{}))
+	assert.True(t, mode == StrictModeNone && found)
+	mode, found = structParseStrictMode(reflect.TypeOf(S5{}))
+	assert.True(t, mode == StrictModeAll && found)
 }
@@ -1,6 +1,7 @@
 package deepcopy
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -65,6 +66,12 @@ func (c *structToMapCopier) init(dstType, srcType reflect.Type) (err error) {
 	srcDirectFields, mapSrcDirectFields, srcInheritedFields, mapSrcInheritedFields := structParseAllFields(srcType)
 	c.fieldCopiers = make([]copier, 0, len(srcDirectFields)+len(srcInheritedFields))
 
+	if c.ctx.hasFieldMasks() {
+		if err = c.ctx.fieldMasksCheckKeys(srcType, mapSrcDirectFields, mapSrcInheritedFields); err != nil {
+			return err
+		}
+	}
+
 	for _, key := range append(srcDirectFields, srcInheritedFields...) {
 		// Find field details from `src` having the key
 		sfDetail := mapSrcDirectFields[key]
@@ -75,6 +82,17 @@ func (c *structToMapCopier) init(dstType, srcType reflect.Type) (err error) {
 			continue
 		}
 
+		// Skip the field when it's excluded by field masks
+		fieldCtx := c.ctx
+		if c.ctx.hasFieldMasks() {
+			only, omit, included := c.ctx.fieldMasksInclude(key)
+			if !included {
+				continue
+			}
+			fieldCtx = c.ctx.withFieldMasks(only, omit)
+		}
+		fieldCtx = fieldCtx.withTimeFormat(sfDetail.timeFormat)
+
 		// Copying methods have higher priority, so if a method defined in the dst struct, use it
 		if dstCopyingMethods != nil {
 			methodName := "Copy" + strings.ToUpper(key[:1]) + key[1:]
@@ -90,7 +108,7 @@ func (c *structToMapCopier) init(dstType, srcType reflect.Type) (err error) {
 			}
 		}
 
-		copier, err := c.buildCopier(mapKeyType, mapValType, srcType, sfDetail, mapKeyNeedConvert)
+		copier, err := c.buildCopier(fieldCtx, mapKeyType, mapValType, srcType, sfDetail, mapKeyNeedConvert)
 		if err != nil {
 			return err
 		}
@@ -101,7 +119,7 @@ func (c *structToMapCopier) init(dstType, srcType reflect.Type) (err error) {
 	return nil
 }
 
-func (c *structToMapCopier) buildCopier(mapKeyType, mapValueType, srcStructType reflect.Type,
+func (c *structToMapCopier) buildCopier(ctx *Context, mapKeyType, mapValueType, srcStructType reflect.Type,
 	srcFieldDetail *fieldDetail, mapKeyNeedConvert bool) (copier, error) {
 	sf := srcFieldDetail.field
 
@@ -110,23 +128,32 @@ func (c *structToMapCopier) buildCopier(mapKeyType, mapValueType, srcStructType
 		mapKey = mapKey.Convert(mapKeyType)
 	}
 
+	// Shallow copy assigns the field value as is
+	if srcFieldDetail.shallow && sf.Type.AssignableTo(mapValueType) {
+		if ctx.hasFieldMasks() {
+			return nil, ctx.fieldMasksErrNotApplicable(mapValueType, sf.Type)
+		}
+		return c.createField2MapEntryCopier(srcFieldDetail, mapKey, nil), nil
+	}
+
 	// OPTIMIZATION: buildCopier() can handle this nicely
-	if simpleKindMask&(1<<sf.Type.Kind()) > 0 {
+	if simpleKindMask&(1<<sf.Type.Kind()) > 0 && !ctx.hasFieldMasks() {
 		if sf.Type == mapValueType {
 			// NOTE: pass nil to unset custom copier and trigger direct copying.
 			// We can pass `&directCopier{}` for the same result (but it's a bit slower).
 			return c.createField2MapEntryCopier(srcFieldDetail, mapKey, nil), nil
 		}
-		if sf.Type.ConvertibleTo(mapValueType) {
+		if ctx.simpleConvertible(mapValueType, sf.Type) {
 			return c.createField2MapEntryCopier(srcFieldDetail, mapKey,
 				&mapItemCopier{dstType: mapValueType, copier: defaultConvCopier}), nil
 		}
 	}
 
-	cp, err := buildCopier(c.ctx, mapValueType, sf.Type)
+	cp, err := buildCopier(ctx, mapValueType, sf.Type)
 	if err != nil {
 		// NOTE: If the copy is not required and the field is unexported, ignore the error
-		if !srcFieldDetail.required && !sf.IsExported() {
+		// unless the field is given in field masks
+		if !srcFieldDetail.required && !sf.IsExported() && !errors.Is(err, ErrFieldMaskInvalid) {
 			return defaultNopCopier, nil
 		}
 		return nil, err
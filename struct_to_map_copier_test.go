@@ -541,3 +541,18 @@ func Test_Copy_structToMap_with_post_copy_event(t *testing.T) {
 		assert.Equal(t, testDstMap3{I: 1, U: 2}, d)
 	})
 }
+
+func Test_Copy_structToMap_with_shallow_copy(t *testing.T) {
+	t.Run("#1: shallow copy via field tag", func(t *testing.T) {
+		type SS struct {
+			S  []int `copy:",shallow"`
+			SS []int
+		}
+		s := SS{S: []int{1, 2}, SS: []int{1, 2}}
+		var d map[string][]int
+		err := Copy(&d, s)
+		assert.Nil(t, err)
+		assert.True(t, &s.S[0] == &d["S"][0])
+		assert.True(t, &s.SS[0] != &d["SS"][0])
+	})
+}
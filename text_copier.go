@@ -0,0 +1,131 @@
+package deepcopy
+
+import (
+	"encoding"
+	"flag"
+	"fmt"
+	"reflect"
+)
+
+var (
+	textMarshalerType   = typeOf[encoding.TextMarshaler]()
+	textUnmarshalerType = typeOf[encoding.TextUnmarshaler]()
+	flagValueType       = typeOf[flag.Value]()
+	stringerType        = typeOf[fmt.Stringer]()
+)
+
+// textMethod method used for reading or writing text of a value
+type textMethod uint8
+
+const (
+	// textMethodNone text is read or written directly as the value is string-like
+	textMethodNone textMethod = iota
+	textMethodMarshaler
+	textMethodStringer
+	textMethodUnmarshaler
+	textMethodFlagValue
+)
+
+// isTextType checks if the type is `string` or `[]byte` or a derived one
+func isTextType(typ reflect.Type) bool {
+	return typ.Kind() == reflect.String || (typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8)
+}
+
+// implements checks if the type or the pointer type implements the interface,
+// returns `ptr` as true if only the pointer type implements it
+func implements(typ, ifaceType reflect.Type) (ok bool, ptr bool) {
+	if typ.Implements(ifaceType) {
+		return true, false
+	}
+	if reflect.PointerTo(typ).Implements(ifaceType) {
+		return true, true
+	}
+	return false, false
+}
+
+// buildCopierForTextTypes builds copier for converting values to and from text via interfaces
+// configured by `TextConversion`, returns nil if the conversion doesn't apply
+func buildCopierForTextTypes(ctx *Context, dstType, srcType reflect.Type) copier {
+	if ctx.TextConversion == TextConvNone || dstType == srcType ||
+		dstType.Kind() == reflect.Pointer || dstType.Kind() == reflect.Interface ||
+		srcType.Kind() == reflect.Pointer || srcType.Kind() == reflect.Interface {
+		return nil
+	}
+
+	// Source value to text
+	if isTextType(dstType) {
+		if ctx.TextConversion&TextConvMarshaler > 0 {
+			if ok, ptr := implements(srcType, textMarshalerType); ok {
+				return &textCopier{srcMethod: textMethodMarshaler, srcPtr: ptr}
+			}
+		}
+		if ctx.TextConversion&TextConvStringer > 0 {
+			if ok, ptr := implements(srcType, stringerType); ok {
+				return &textCopier{srcMethod: textMethodStringer, srcPtr: ptr}
+			}
+		}
+	}
+
+	// Text to destination value
+	if isTextType(srcType) {
+		if ctx.TextConversion&TextConvMarshaler > 0 && reflect.PointerTo(dstType).Implements(textUnmarshalerType) {
+			return &textCopier{dstMethod: textMethodUnmarshaler}
+		}
+		if ctx.TextConversion&TextConvFlagValue > 0 && reflect.PointerTo(dstType).Implements(flagValueType) {
+			return &textCopier{dstMethod: textMethodFlagValue}
+		}
+	}
+	return nil
+}
+
+// textCopier data structure of copier that converts values to and from text
+type textCopier struct {
+	srcMethod textMethod
+	srcPtr    bool
+	dstMethod textMethod
+}
+
+// Copy implementation of Copy function for text copier
+func (c *textCopier) Copy(dst, src reflect.Value) (err error) {
+	if c.srcPtr && !src.CanAddr() {
+		srcCopy := reflect.New(src.Type()).Elem()
+		srcCopy.Set(src)
+		src = srcCopy
+	}
+	srcIface := func() any {
+		if c.srcPtr {
+			return src.Addr().Interface()
+		}
+		return src.Interface()
+	}
+
+	var text []byte
+	switch c.srcMethod {
+	case textMethodMarshaler:
+		if text, err = srcIface().(encoding.TextMarshaler).MarshalText(); err != nil { //nolint:forcetypeassert
+			return err
+		}
+	case textMethodStringer:
+		text = []byte(srcIface().(fmt.Stringer).String()) //nolint:forcetypeassert
+	default:
+		if src.Kind() == reflect.String {
+			text = []byte(src.String())
+		} else {
+			text = src.Bytes()
+		}
+	}
+
+	switch c.dstMethod {
+	case textMethodUnmarshaler:
+		return dst.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText(text) //nolint:forcetypeassert
+	case textMethodFlagValue:
+		return dst.Addr().Interface().(flag.Value).Set(string(text)) //nolint:forcetypeassert
+	default:
+		if dst.Kind() == reflect.String {
+			dst.SetString(string(text))
+		} else {
+			dst.SetBytes(append([]byte{}, text...))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package deepcopy
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testColor implements encoding.TextMarshaler and encoding.TextUnmarshaler
+type testColor int
+
+var testColorNames = []string{"none", "red", "green"}
+
+func (c testColor) MarshalText() ([]byte, error) {
+	if int(c) >= len(testColorNames) {
+		return nil, fmt.Errorf("%w: invalid color %d", errTest, c)
+	}
+	return []byte(testColorNames[c]), nil
+}
+
+func (c *testColor) UnmarshalText(text []byte) error {
+	for i, name := range testColorNames {
+		if name == string(text) {
+			*c = testColor(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: invalid color %s", errTest, text)
+}
+
+// testLevel implements flag.Value
+type testLevel struct {
+	level int
+}
+
+func (l *testLevel) String() string {
+	return strings.Repeat("*", l.level)
+}
+
+func (l *testLevel) Set(s string) error {
+	l.level = len(s)
+	return nil
+}
+
+// testCode implements fmt.Stringer
+type testCode int
+
+func (c testCode) String() string {
+	return fmt.Sprintf("C%03d", int(c))
+}
+
+func Test_Copy_textConversion(t *testing.T) {
+	t.Run("#1: struct fields to text", func(t *testing.T) {
+		type SS struct {
+			C  testColor
+			PC *testColor
+			B  testColor
+			L  testLevel
+			S  testCode
+			IP netip.Addr
+		}
+		type DD struct {
+			C  string
+			PC *string
+			B  []byte
+			L  string
+			S  string
+			IP string
+		}
+		s := SS{C: 1, PC: ptrOf(testColor(2)), B: 2, L: testLevel{level: 3}, S: 7,
+			IP: netip.MustParseAddr("10.0.0.1")}
+		var d DD
+		err := Copy(&d, s, TextConversion(TextConvAll))
+		assert.Nil(t, err)
+		assert.Equal(t, DD{C: "red", PC: ptrOf("green"), B: []byte("green"), L: "***", S: "C007", IP: "10.0.0.1"}, d)
+	})
+
+	t.Run("#2: text to struct fields", func(t *testing.T) {
+		type SS struct {
+			C  string
+			PC *string
+			B  []byte
+			L  string
+			IP string
+		}
+		type DD struct {
+			C  testColor
+			PC *testColor
+			B  testColor
+			L  testLevel
+			IP netip.Addr
+		}
+		s := SS{C: "red", PC: ptrOf("green"), B: []byte("green"), L: "***", IP: "10.0.0.1"}
+		var d DD
+		err := Copy(&d, s, TextConversion(TextConvAll))
+		assert.Nil(t, err)
+		assert.Equal(t, DD{C: 1, PC: ptrOf(testColor(2)), B: 2, L: testLevel{level: 3},
+			IP: netip.MustParseAddr("10.0.0.1")}, d)
+	})
+
+	t.Run("#3: map values and map to struct", func(t *testing.T) {
+		var d map[string]string
+		err := Copy(&d, map[string]testColor{"a": 1, "b": 2}, TextConversion(TextConvMarshaler))
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"a": "red", "b": "green"}, d)
+
+		type DD struct {
+			C testColor
+			L *testLevel
+		}
+		var d2 DD
+		err = Copy(&d2, map[string]any{"C": "green", "L": "**"}, TextConversion(TextConvAll))
+		assert.Nil(t, err)
+		assert.Equal(t, DD{C: 2, L: &testLevel{level: 2}}, d2)
+
+		var d3 map[string]any
+		err = Copy(&d3, DD{C: 1}, TextConversion(TextConvAll))
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]any{"C": testColor(1), "L": (*testLevel)(nil)}, d3)
+	})
+
+	t.Run("#4: only the configured conversions apply", func(t *testing.T) {
+		var d string
+		err := Copy(&d, testCode(7), TextConversion(TextConvMarshaler))
+		assert.Nil(t, err)
+		assert.Equal(t, "\a", d) // Go conversion from int to string
+
+		var d2 testLevel
+		err = Copy(&d2, "***", TextConversion(TextConvMarshaler|TextConvStringer))
+		assert.ErrorIs(t, err, ErrTypeNonCopyable)
+
+		var d3 string
+		err = Copy(&d3, testColor(1))
+		assert.Nil(t, err)
+		assert.Equal(t, "\x01", d3)
+	})
+
+	t.Run("#5: conversion errors", func(t *testing.T) {
+		var d string
+		err := Copy(&d, testColor(5), TextConversion(TextConvAll))
+		assert.ErrorIs(t, err, errTest)
+
+		var d2 testColor
+		err = Copy(&d2, "blue", TextConversion(TextConvAll))
+		assert.ErrorIs(t, err, errTest)
+	})
+}
@@ -0,0 +1,206 @@
+package deepcopy
+
+import (
+	"reflect"
+	"time"
+)
+
+var (
+	timeType     = typeOf[time.Time]()
+	durationType = typeOf[time.Duration]()
+
+	// timeLayouts named layouts which can be used in tag option `layout`
+	timeLayouts = map[string]string{
+		"ANSIC":       time.ANSIC,
+		"UnixDate":    time.UnixDate,
+		"RubyDate":    time.RubyDate,
+		"RFC822":      time.RFC822,
+		"RFC822Z":     time.RFC822Z,
+		"RFC850":      time.RFC850,
+		"RFC1123":     time.RFC1123,
+		"RFC1123Z":    time.RFC1123Z,
+		"RFC3339":     time.RFC3339,
+		"RFC3339Nano": time.RFC3339Nano,
+		"Kitchen":     time.Kitchen,
+		"Stamp":       time.Stamp,
+		"StampMilli":  time.StampMilli,
+		"StampMicro":  time.StampMicro,
+		"StampNano":   time.StampNano,
+		// NOTE: Go1.18 has no time.DateTime, time.DateOnly, time.TimeOnly
+		"DateTime": "2006-01-02 15:04:05",
+		"DateOnly": "2006-01-02",
+		"TimeOnly": "15:04:05",
+	}
+
+	// timeUnixUnits units of Unix time which can be used in tag option `unix`
+	timeUnixUnits = map[string]time.Duration{
+		"s":  time.Second,
+		"ms": time.Millisecond,
+		"us": time.Microsecond,
+		"ns": time.Nanosecond,
+	}
+)
+
+const (
+	// defaultTimeLayout layout used for converting time.Time from and to string when not configured
+	defaultTimeLayout = time.RFC3339Nano
+)
+
+// timeFormat format of converting time values parsed from struct tag options
+// `layout=<name or layout>` (quoted as `layout='<layout>'` when containing commas), `unix=<s|ms|us|ns>` and `utc`
+type timeFormat struct {
+	layout   string
+	unixUnit time.Duration
+	utc      bool
+}
+
+// merge returns the format with unset settings taken from the other format
+func (f timeFormat) merge(other timeFormat) timeFormat {
+	if f.layout == "" {
+		f.layout = other.layout
+	}
+	if f.unixUnit == 0 {
+		f.unixUnit = other.unixUnit
+	}
+	f.utc = f.utc || other.utc
+	return f
+}
+
+// withTimeFormat returns a context with the given time format applied
+func (ctx *Context) withTimeFormat(format timeFormat) *Context {
+	if ctx.timeFormat == format {
+		return ctx
+	}
+	options := *ctx.copierOptions
+	options.timeFormat = format
+	return ctx.withOptions(options)
+}
+
+// timeConv kind of time conversion
+type timeConv uint8
+
+const (
+	timeConvTimeToTime timeConv = iota
+	timeConvTimeToString
+	timeConvStringToTime
+	timeConvTimeToUnix
+	timeConvUnixToTime
+	timeConvDurationToString
+	timeConvStringToDuration
+)
+
+// isIntKind checks if the kind is a signed or unsigned integer kind
+func isIntKind(kind reflect.Kind) bool {
+	return (kind >= reflect.Int && kind <= reflect.Int64) || (kind >= reflect.Uint && kind <= reflect.Uint64)
+}
+
+// buildCopierForTimeTypes builds copier for converting time.Time and time.Duration from and to
+// other types following the time format of the context, returns nil if the conversion doesn't apply.
+// Conversions from and to strings apply to values having no time tag options only when TimeConversion is set.
+func buildCopierForTimeTypes(ctx *Context, dstType, srcType reflect.Type) copier {
+	dstKind, srcKind := dstType.Kind(), srcType.Kind()
+	dstTime := dstType == timeType || (dstKind == reflect.Struct && dstType.ConvertibleTo(timeType))
+	format := ctx.timeFormat
+	textConv := ctx.TimeConversion || format != timeFormat{}
+	if format.layout == "" {
+		format.layout = defaultTimeLayout
+	}
+
+	switch {
+	case srcType == timeType && dstTime:
+		if !format.utc {
+			return nil // Copied as usual
+		}
+		return &timeCopier{conv: timeConvTimeToTime, format: format}
+	case srcType == timeType && dstKind == reflect.String && textConv:
+		return &timeCopier{conv: timeConvTimeToString, format: format}
+	case srcType == timeType && isIntKind(dstKind) && format.unixUnit > 0:
+		return &timeCopier{conv: timeConvTimeToUnix, format: format}
+	case dstTime && srcKind == reflect.String && textConv:
+		return &timeCopier{conv: timeConvStringToTime, format: format}
+	case dstTime && isIntKind(srcKind) && format.unixUnit > 0:
+		return &timeCopier{conv: timeConvUnixToTime, format: format}
+	case srcType == durationType && dstKind == reflect.String && ctx.TimeConversion:
+		return &timeCopier{conv: timeConvDurationToString}
+	case dstType == durationType && srcKind == reflect.String && ctx.TimeConversion:
+		return &timeCopier{conv: timeConvStringToDuration}
+	}
+	return nil
+}
+
+// timeCopier data structure of copier that converts time.Time and time.Duration from and to other types
+type timeCopier struct {
+	conv   timeConv
+	format timeFormat
+}
+
+// Copy implementation of Copy function for time copier
+//
+//nolint:forcetypeassert
+func (c *timeCopier) Copy(dst, src reflect.Value) error {
+	switch c.conv {
+	case timeConvTimeToTime:
+		c.setTime(dst, src.Interface().(time.Time))
+	case timeConvTimeToString:
+		dst.SetString(c.normalize(src.Interface().(time.Time)).Format(c.format.layout))
+	case timeConvStringToTime:
+		if src.Len() == 0 {
+			dst.Set(reflect.Zero(dst.Type())) // NOTE: Go1.18 has no SetZero
+			return nil
+		}
+		t, err := time.Parse(c.format.layout, src.String())
+		if err != nil {
+			return err
+		}
+		c.setTime(dst, t)
+	case timeConvTimeToUnix:
+		v := c.toUnix(src.Interface().(time.Time))
+		if dst.CanInt() {
+			dst.SetInt(v)
+		} else {
+			dst.SetUint(uint64(v)) //nolint:gosec
+		}
+	case timeConvUnixToTime:
+		var v int64
+		if src.CanInt() {
+			v = src.Int()
+		} else {
+			v = int64(src.Uint()) //nolint:gosec
+		}
+		c.setTime(dst, c.fromUnix(v))
+	case timeConvDurationToString:
+		dst.SetString(time.Duration(src.Int()).String())
+	case timeConvStringToDuration:
+		d, err := time.ParseDuration(src.String())
+		if err != nil {
+			return err
+		}
+		dst.SetInt(int64(d))
+	}
+	return nil
+}
+
+// normalize normalizes the time zone of the time value
+func (c *timeCopier) normalize(t time.Time) time.Time {
+	if c.format.utc {
+		return t.UTC()
+	}
+	return t
+}
+
+// setTime sets the time value to the destination of time.Time or a derived type
+func (c *timeCopier) setTime(dst reflect.Value, t time.Time) {
+	dst.Set(reflect.ValueOf(c.normalize(t)).Convert(dst.Type()))
+}
+
+// toUnix converts the time value to Unix time of the configured unit
+func (c *timeCopier) toUnix(t time.Time) int64 {
+	unit := int64(c.format.unixUnit)
+	return t.Unix()*(int64(time.Second)/unit) + int64(t.Nanosecond())/unit
+}
+
+// fromUnix converts Unix time of the configured unit to time value
+func (c *timeCopier) fromUnix(v int64) time.Time {
+	unitsPerSec := int64(time.Second / c.format.unixUnit)
+	return time.Unix(v/unitsPerSec, (v%unitsPerSec)*int64(c.format.unixUnit))
+}
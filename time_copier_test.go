@@ -0,0 +1,211 @@
+package deepcopy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Copy_timeConversion(t *testing.T) {
+	loc := time.FixedZone("UTC+7", 7*60*60)
+	tm := time.Date(2024, 5, 6, 7, 8, 9, 123456789, loc)
+
+	t.Run("#1: time.Time -> string", func(t *testing.T) {
+		type SS struct {
+			T1 time.Time
+			T2 time.Time `copy:",layout=RFC3339"`
+			T3 time.Time
+			T4 *time.Time
+			T5 []time.Time `copy:",layout=DateOnly"`
+		}
+		type DD struct {
+			T1 string
+			T2 string
+			T3 string  `copy:",layout=15:04,utc"`
+			T4 *string `copy:",layout=RFC3339,utc"`
+			T5 []string
+		}
+		s := SS{T1: tm, T2: tm, T3: tm, T4: &tm, T5: []time.Time{tm}}
+		var d DD
+		err := Copy(&d, s, TimeConversion(true))
+		assert.Nil(t, err)
+		assert.Equal(t, DD{
+			T1: "2024-05-06T07:08:09.123456789+07:00",
+			T2: "2024-05-06T07:08:09+07:00",
+			T3: "00:08",
+			T4: ptrOf("2024-05-06T00:08:09Z"),
+			T5: []string{"2024-05-06"},
+		}, d)
+	})
+
+	t.Run("#2: string -> time.Time", func(t *testing.T) {
+		type SS struct {
+			T1 string
+			T2 string `copy:",layout=RFC3339,utc"`
+			T3 *string
+			T4 string
+		}
+		type DD struct {
+			T1 time.Time
+			T2 time.Time
+			T3 *time.Time `copy:",layout=DateOnly"`
+			T4 time.Time
+		}
+		s := SS{T1: "2024-05-06T07:08:09.123456789+07:00", T2: "2024-05-06T07:08:09+07:00", T3: ptrOf("2024-05-06")}
+		d := DD{T4: tm}
+		err := Copy(&d, s, TimeConversion(true))
+		assert.Nil(t, err)
+		assert.True(t, tm.Equal(d.T1))
+		assert.Equal(t, time.Date(2024, 5, 6, 0, 8, 9, 0, time.UTC), d.T2)
+		assert.Equal(t, time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC), *d.T3)
+		assert.True(t, d.T4.IsZero())
+
+		var d2 DD
+		err = Copy(&d2, SS{T1: "06/05/2024"}, TimeConversion(true))
+		assert.ErrorContains(t, err, "cannot parse")
+	})
+
+	t.Run("#3: time.Time <-> unix time", func(t *testing.T) {
+		type SS struct {
+			S  time.Time `copy:",unix=s"`
+			MS time.Time `copy:",unix=ms"`
+			US time.Time
+			NS time.Time
+		}
+		type DD struct {
+			S  int64
+			MS uint64
+			US int64  `copy:",unix=us"`
+			NS *int64 `copy:",unix=ns"`
+		}
+		s := SS{S: tm, MS: tm, US: tm, NS: tm}
+		var d DD
+		err := Copy(&d, s)
+		assert.Nil(t, err)
+		assert.Equal(t, DD{S: tm.Unix(), MS: uint64(tm.UnixMilli()), US: tm.UnixMicro(), NS: ptrOf(tm.UnixNano())}, d)
+
+		var s2 SS
+		err = Copy(&s2, d)
+		assert.Nil(t, err)
+		assert.True(t, tm.Truncate(time.Second).Equal(s2.S))
+		assert.True(t, tm.Truncate(time.Millisecond).Equal(s2.MS))
+		assert.True(t, tm.Truncate(time.Microsecond).Equal(s2.US))
+		assert.True(t, tm.Equal(s2.NS))
+
+		// Negative unix time
+		type TT struct {
+			T time.Time `copy:",utc"`
+		}
+		var d4 TT
+		err = Copy(&d4, struct {
+			T int64 `copy:",unix=ms"`
+		}{T: -1500})
+		assert.Nil(t, err)
+		assert.Equal(t, time.Date(1969, 12, 31, 23, 59, 58, 500000000, time.UTC), d4.T)
+	})
+
+	t.Run("#4: time.Time -> time.Time with utc", func(t *testing.T) {
+		type MyTime time.Time
+		type SS struct {
+			T1 time.Time `copy:",utc"`
+			T2 time.Time
+		}
+		type DD struct {
+			T1 MyTime
+			T2 time.Time
+		}
+		var d DD
+		err := Copy(&d, SS{T1: tm, T2: tm})
+		assert.Nil(t, err)
+		assert.Equal(t, time.UTC, time.Time(d.T1).Location())
+		assert.Equal(t, loc, d.T2.Location())
+	})
+
+	t.Run("#5: time.Duration <-> string", func(t *testing.T) {
+		type SS struct {
+			D1 time.Duration
+			D2 *time.Duration
+			D3 string
+		}
+		type DD struct {
+			D1 string
+			D2 string
+			D3 time.Duration
+		}
+		var d DD
+		err := Copy(&d, SS{D1: 90 * time.Second, D2: ptrOf(time.Millisecond), D3: "1h30m"}, TimeConversion(true))
+		assert.Nil(t, err)
+		assert.Equal(t, DD{D1: "1m30s", D2: "1ms", D3: 90 * time.Minute}, d)
+
+		var d2 time.Duration
+		err = Copy(&d2, "1 hour", TimeConversion(true))
+		assert.ErrorContains(t, err, "unknown unit")
+	})
+
+	t.Run("#6: struct -> map and map -> struct", func(t *testing.T) {
+		type SS struct {
+			T time.Time `copy:",layout=DateOnly"`
+			D time.Duration
+		}
+		var d map[string]string
+		err := Copy(&d, SS{T: tm, D: time.Second}, TimeConversion(true))
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"T": "2024-05-06", "D": "1s"}, d)
+
+		var s SS
+		err = Copy(&s, map[string]any{"T": "2024-05-06", "D": "1s"}, TimeConversion(true))
+		assert.Nil(t, err)
+		assert.Equal(t, SS{T: time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC), D: time.Second}, s)
+	})
+
+	t.Run("#7: quoted layout containing commas", func(t *testing.T) {
+		type SS struct {
+			T time.Time `copy:",layout='Mon, 02 Jan 2006',utc"`
+		}
+		type DD struct {
+			T string
+		}
+		var d DD
+		err := Copy(&d, SS{T: tm})
+		assert.Nil(t, err)
+		assert.Equal(t, DD{T: tm.UTC().Format("Mon, 02 Jan 2006")}, d)
+
+		var s SS
+		err = Copy(&s, DD{T: "Mon, 06 May 2024"})
+		assert.Nil(t, err)
+		assert.Equal(t, SS{T: time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)}, s)
+	})
+
+	t.Run("#8: values without time tag options", func(t *testing.T) {
+		// Not converted from and to strings by default
+		var d string
+		err := Copy(&d, tm)
+		assert.ErrorIs(t, err, ErrTypeNonCopyable)
+
+		var d2 time.Time
+		err = Copy(&d2, "2024-05-06T07:08:09+07:00")
+		assert.ErrorIs(t, err, ErrTypeNonCopyable)
+
+		var d3 time.Duration
+		err = Copy(&d3, "1s")
+		assert.ErrorIs(t, err, ErrTypeNonCopyable)
+
+		// Converted using the default layout and the duration format when TimeConversion is set
+		err = Copy(&d, tm, TimeConversion(true))
+		assert.Nil(t, err)
+		assert.Equal(t, "2024-05-06T07:08:09.123456789+07:00", d)
+
+		err = Copy(&d2, d, TimeConversion(true))
+		assert.Nil(t, err)
+		assert.True(t, tm.Equal(d2))
+
+		err = Copy(&d, time.Second, TimeConversion(true))
+		assert.Nil(t, err)
+		assert.Equal(t, "1s", d)
+
+		err = Copy(&d3, "1m", TimeConversion(true))
+		assert.Nil(t, err)
+		assert.Equal(t, time.Minute, d3)
+	})
+}
@@ -2,7 +2,11 @@ package deepcopy
 
 import (
 	"reflect"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"unsafe"
 )
 
 var (
@@ -12,9 +16,124 @@ var (
 )
 
 const (
-	typeMethodPostCopy = "PostCopy"
+	typeMethodPostCopy     = "PostCopy"
+	typeMethodDeepCopyInto = "DeepCopyInto"
+	typeMethodDeepCopy     = "DeepCopy"
+	typeMethodClone        = "Clone"
 )
 
+const (
+	// maxInternedValues max number of values kept by an intern map
+	maxInternedValues = 1024
+)
+
+// internMap map of interned values, it's safe for concurrent use.
+// Reads are lock-free as values are interned once and read many times, additions copy the map.
+// The map is bounded: when it's full, it starts over. Values interned before stay valid,
+// but equal values interned after that are new ones.
+type internMap[K comparable, V any] struct {
+	m  atomic.Value // map[K]V
+	mu sync.Mutex
+}
+
+// get returns the value interned for the key
+func (im *internMap[K, V]) get(key K) (V, bool) {
+	m, _ := im.m.Load().(map[K]V)
+	val, found := m[key]
+	return val, found
+}
+
+// add interns the value for the key unless there is one already, returns the interned value
+func (im *internMap[K, V]) add(key K, val V) V {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+	oldMap, _ := im.m.Load().(map[K]V)
+	if oldVal, found := oldMap[key]; found {
+		return oldVal
+	}
+	if len(oldMap) >= maxInternedValues {
+		oldMap = nil
+	}
+	newMap := make(map[K]V, len(oldMap)+1)
+	for k, v := range oldMap {
+		newMap[k] = v
+	}
+	newMap[key] = val
+	im.m.Store(newMap)
+	return val
+}
+
+// len returns the number of interned values
+func (im *internMap[K, V]) len() int {
+	m, _ := im.m.Load().(map[K]V)
+	return len(m)
+}
+
+// typeSet immutable set of types.
+// Sets are interned, so equal sets share the same pointer which can be used as a part of cache keys.
+type typeSet struct {
+	types map[reflect.Type]struct{}
+}
+
+var (
+	// internedTypeSets interned type sets by keys of their types
+	internedTypeSets internMap[string, *typeSet]
+)
+
+// has checks if the set contains the type
+func (s *typeSet) has(typ reflect.Type) bool {
+	if s == nil {
+		return false
+	}
+	_, exists := s.types[typ]
+	return exists
+}
+
+// internTypeSet returns the interned set of the given types
+func internTypeSet(types []reflect.Type) *typeSet {
+	if len(types) == 0 {
+		return nil
+	}
+	key := typeSetKey(types)
+	if s, found := internedTypeSets.get(key); found {
+		return s
+	}
+	typeMap := make(map[reflect.Type]struct{}, len(types))
+	for _, typ := range types {
+		typeMap[typ] = struct{}{}
+	}
+	return internedTypeSets.add(key, &typeSet{types: typeMap})
+}
+
+// typeSetKey returns the key of a set of types made of the addresses of the types.
+// The addresses are sorted, so the key doesn't depend on the order of the types.
+func typeSetKey(types []reflect.Type) string {
+	addrs := make([]uintptr, 0, len(types))
+	for _, typ := range types {
+		if typ != nil {
+			addrs = append(addrs, reflect.ValueOf(typ).Pointer())
+		}
+	}
+	sort.Slice(addrs, func(i, j int) bool { return addrs[i] < addrs[j] })
+
+	const addrSize = int(unsafe.Sizeof(uintptr(0)))
+	key := make([]byte, 0, len(addrs)*addrSize)
+	for i, addr := range addrs {
+		if i > 0 && addr == addrs[i-1] {
+			continue
+		}
+		for j := 0; j < addrSize; j++ {
+			key = append(key, byte(addr>>(8*j))) //nolint:mnd
+		}
+	}
+	return string(key)
+}
+
+// typeOf returns reflect type of the type parameter
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
 // typeParseMethods collects all copying methods from the given type
 func typeParseMethods(ctx *Context, typ reflect.Type) (
 	copyingMethods map[string]*reflect.Method, postCopyMethod *reflect.Method) {
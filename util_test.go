@@ -0,0 +1,41 @@
+package deepcopy
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_internMap(t *testing.T) {
+	var im internMap[int, *int]
+	_, found := im.get(1)
+	assert.False(t, found)
+
+	v1 := ptrOf(1)
+	assert.True(t, v1 == im.add(1, v1))
+	assert.True(t, v1 == im.add(1, ptrOf(1)))
+	v, found := im.get(1)
+	assert.True(t, found)
+	assert.True(t, v1 == v)
+
+	// The map starts over when it's full
+	for i := 2; i <= maxInternedValues+1; i++ {
+		im.add(i, ptrOf(i))
+	}
+	assert.Equal(t, 1, im.len())
+	_, found = im.get(1)
+	assert.False(t, found)
+}
+
+func Test_internTypeSet(t *testing.T) {
+	assert.Nil(t, internTypeSet(nil))
+
+	s1 := internTypeSet([]reflect.Type{typeOf[int](), typeOf[string]()})
+	s2 := internTypeSet([]reflect.Type{typeOf[string](), typeOf[int](), typeOf[int]()})
+	s3 := internTypeSet([]reflect.Type{typeOf[string]()})
+	assert.True(t, s1 == s2)
+	assert.True(t, s1 != s3)
+	assert.True(t, s1.has(typeOf[int]()))
+	assert.False(t, s3.has(typeOf[int]()))
+}